@@ -0,0 +1,51 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+// Package fakefs builds the fake procfs and sysfs trees the tests run on.
+// Every helper fails the test on error.
+package fakefs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Mkdir creates the directory `path`, with the missing parents
+func Mkdir(t testing.TB, path string) {
+	t.Helper()
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatalf("MkdirAll(%s) failed: %v", path, err)
+	}
+}
+
+// WriteFile writes `content` in the file `path`, creating the missing parent directories
+func WriteFile(t testing.TB, path, content string) {
+	t.Helper()
+	Mkdir(t, filepath.Dir(path))
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile(%s) failed: %v", path, err)
+	}
+}
+
+// Symlink creates `newname` as a symbolic link to `oldname`, like sysfs links devices and drivers
+func Symlink(t testing.TB, oldname, newname string) {
+	t.Helper()
+	if err := os.Symlink(oldname, newname); err != nil {
+		t.Fatalf("Symlink(%s, %s) failed: %v", oldname, newname, err)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2026 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cgroups
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+const (
+	QOSGuaranteed = "Guaranteed"
+	QOSBurstable  = "Burstable"
+	QOSBestEffort = "BestEffort"
+)
+
+// container runtimes scope prefixes, when using the systemd cgroup driver
+var scopePrefixes = []string{
+	"crio-",
+	"cri-containerd-",
+	"docker-",
+}
+
+// KubeInfo is the kubernetes identity of a cgroup. All fields are empty
+// if the cgroup does not belong to the kubepods hierarchy.
+type KubeInfo struct {
+	PodUID      string `json:"podUID,omitempty"`
+	ContainerID string `json:"containerID,omitempty"`
+	QOSClass    string `json:"qosClass,omitempty"`
+}
+
+// ParseProcCgroup extracts the cgroup path from the content of /proc/<pid>/cgroup.
+// If the process is on the cgroup v2 unified hierarchy, returns that path; otherwise,
+// returns the path on the cpuset controller, because it is the one we care about the most.
+// Returns empty string if no suitable path is found.
+func ParseProcCgroup(rd io.Reader) (string, error) {
+	var cpusetPath, firstPath string
+	scanner := bufio.NewScanner(rd)
+	for scanner.Scan() {
+		// format: hierarchy-ID:controller-list:cgroup-path, see man 7 cgroups
+		items := strings.SplitN(scanner.Text(), ":", 3)
+		if len(items) != 3 {
+			continue
+		}
+		if items[0] == "0" && items[1] == "" {
+			return items[2], nil
+		}
+		if firstPath == "" {
+			firstPath = items[2]
+		}
+		for _, ctrl := range strings.Split(items[1], ",") {
+			if ctrl == "cpuset" {
+				cpusetPath = items[2]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if cpusetPath != "" {
+		return cpusetPath, nil
+	}
+	return firstPath, nil
+}
+
+// ParseKubepodsPath extracts the pod UID, the QoS class and the container ID from a cgroup path.
+// Both the systemd and the cgroupfs cgroup driver layouts are supported:
+// /kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod<UID>.slice/crio-<ID>.scope
+// /kubepods/burstable/pod<UID>/<ID>
+func ParseKubepodsPath(cgroupPath string) KubeInfo {
+	info := KubeInfo{}
+	items := strings.Split(strings.Trim(cgroupPath, "/"), "/")
+	if len(items) == 0 || (items[0] != "kubepods.slice" && items[0] != "kubepods") {
+		return info
+	}
+	info.QOSClass = QOSGuaranteed
+	for _, item := range items[1:] {
+		switch {
+		case item == "burstable" || item == "kubepods-burstable.slice":
+			info.QOSClass = QOSBurstable
+		case item == "besteffort" || item == "kubepods-besteffort.slice":
+			info.QOSClass = QOSBestEffort
+		case info.PodUID == "" && strings.HasSuffix(item, ".slice"):
+			// kubepods-pod<UID>.slice, kubepods-burstable-pod<UID>.slice
+			if off := strings.LastIndex(item, "-pod"); off >= 0 {
+				uid := strings.TrimSuffix(item[off+len("-pod"):], ".slice")
+				// systemd escapes the dashes
+				info.PodUID = strings.ReplaceAll(uid, "_", "-")
+			}
+		case info.PodUID == "" && strings.HasPrefix(item, "pod"):
+			info.PodUID = strings.TrimPrefix(item, "pod")
+		case info.PodUID != "" && info.ContainerID == "":
+			info.ContainerID = containerIDFromScope(item)
+		}
+	}
+	if info.PodUID == "" {
+		// kubepods but not a pod, like the QoS slices themselves
+		return KubeInfo{}
+	}
+	return info
+}
+
+func containerIDFromScope(item string) string {
+	if !strings.HasSuffix(item, ".scope") {
+		// cgroupfs driver
+		return item
+	}
+	scope := strings.TrimSuffix(item, ".scope")
+	// crio-conmon-<ID>.scope is the container monitor, not the container
+	if strings.HasPrefix(scope, "crio-conmon-") {
+		return ""
+	}
+	for _, prefix := range scopePrefixes {
+		if strings.HasPrefix(scope, prefix) {
+			return strings.TrimPrefix(scope, prefix)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2026 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cgroups
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseProcCgroup(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "empty",
+			content:  "",
+			expected: "",
+		},
+		{
+			name:     "cgroup v2",
+			content:  "0::/kubepods.slice/kubepods-pod1234.slice/crio-abcd.scope\n",
+			expected: "/kubepods.slice/kubepods-pod1234.slice/crio-abcd.scope",
+		},
+		{
+			name:     "cgroup v1",
+			content:  "12:memory:/kubepods/pod1234/abcd\n11:cpuset:/kubepods/pod1234/efgh\n1:name=systemd:/kubepods/pod1234/abcd\n",
+			expected: "/kubepods/pod1234/efgh",
+		},
+		{
+			name:     "cgroup v1 no cpuset",
+			content:  "12:memory:/system.slice/sshd.service\n1:name=systemd:/system.slice/sshd.service\n",
+			expected: "/system.slice/sshd.service",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseProcCgroup(strings.NewReader(tt.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("got %q expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseKubepodsPath(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		expected KubeInfo
+	}{
+		{
+			name: "not a pod",
+			path: "/system.slice/crio.service",
+		},
+		{
+			name: "qos slice",
+			path: "/kubepods.slice/kubepods-burstable.slice",
+		},
+		{
+			name: "systemd guaranteed",
+			path: "/kubepods.slice/kubepods-pod6f7b6ffa_2d4f_4a5e_9c1b_0a7f3c2d1e00.slice/crio-0123456789abcdef.scope",
+			expected: KubeInfo{
+				PodUID:      "6f7b6ffa-2d4f-4a5e-9c1b-0a7f3c2d1e00",
+				ContainerID: "0123456789abcdef",
+				QOSClass:    QOSGuaranteed,
+			},
+		},
+		{
+			name: "systemd burstable nested container cgroup",
+			path: "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod6f7b6ffa_2d4f.slice/cri-containerd-0123.scope/container",
+			expected: KubeInfo{
+				PodUID:      "6f7b6ffa-2d4f",
+				ContainerID: "0123",
+				QOSClass:    QOSBurstable,
+			},
+		},
+		{
+			name: "systemd conmon",
+			path: "/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod6f7b6ffa.slice/crio-conmon-0123.scope",
+			expected: KubeInfo{
+				PodUID:   "6f7b6ffa",
+				QOSClass: QOSBestEffort,
+			},
+		},
+		{
+			name: "cgroupfs burstable",
+			path: "/kubepods/burstable/pod6f7b6ffa-2d4f/0123",
+			expected: KubeInfo{
+				PodUID:      "6f7b6ffa-2d4f",
+				ContainerID: "0123",
+				QOSClass:    QOSBurstable,
+			},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseKubepodsPath(tt.path)
+			if got != tt.expected {
+				t.Errorf("got %+v expected %+v", got, tt.expected)
+			}
+		})
+	}
+}
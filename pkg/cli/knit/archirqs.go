@@ -0,0 +1,115 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
+	"github.com/openshift-kni/debug-tools/pkg/isolation"
+)
+
+type archIRQsOptions struct {
+	period string
+}
+
+func NewArchIRQsCommand(knitOpts *KnitOptions) *cobra.Command {
+	opts := &archIRQsOptions{}
+	archIRQs := &cobra.Command{
+		Use:   "archirqs",
+		Short: "show the IPIs and the architecture-specific interrupts the isolated cpus receive",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showArchIRQs(cmd, knitOpts, opts, args)
+		},
+		Args: cobra.NoArgs,
+	}
+	archIRQs.Flags().StringVarP(&opts.period, "watch-period", "W", "5s", "period to sample the interrupt counters. Interrupt to stop earlier.")
+	return archIRQs
+}
+
+type archIRQsReport struct {
+	Isolated []int            `json:"isolated"`
+	Elapsed  string           `json:"elapsed"`
+	Usage    []irqs.ArchUsage `json:"usage"`
+	// Flagged are the isolated cpus which received any architecture-specific interrupt
+	Flagged []int `json:"flagged"`
+}
+
+func showArchIRQs(cmd *cobra.Command, knitOpts *KnitOptions, opts *archIRQsOptions, args []string) error {
+	period, err := time.ParseDuration(opts.period)
+	if err != nil {
+		return err
+	}
+
+	info, err := isolation.New(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.SysFSRoot).ReadInfo()
+	if err != nil {
+		return err
+	}
+	isolated, _ := IsolatedCPUs(knitOpts, info)
+
+	ih := irqs.New(knitOpts.Log, knitOpts.ProcFSRoot)
+	initTs := time.Now()
+	initStats, _, err := ih.ReadInterrupts()
+	if err != nil {
+		return err
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+	select {
+	case <-c:
+	case <-time.After(period):
+	}
+
+	lastStats, descs, err := ih.ReadInterrupts()
+	if err != nil {
+		return err
+	}
+	elapsed := time.Now().Sub(initTs)
+
+	report := archIRQsReport{
+		Isolated: isolated.List(),
+		Elapsed:  elapsed.String(),
+		Usage:    irqs.AnalyzeArch(isolated, initStats.Delta(lastStats), descs, elapsed),
+	}
+	for _, au := range report.Usage {
+		if len(report.Flagged) == 0 || report.Flagged[len(report.Flagged)-1] != au.CPU {
+			report.Flagged = append(report.Flagged, au.CPU)
+		}
+	}
+
+	if knitOpts.JsonOutput {
+		json.NewEncoder(os.Stdout).Encode(report)
+		return nil
+	}
+	fmt.Printf("architecture-specific interrupts on isolated cpus %v after %v\n", report.Isolated, report.Elapsed)
+	for _, au := range report.Usage {
+		fmt.Printf("CPU=%d %-4s [%-8s] +%d (%.2f/s): %s\n", au.CPU, au.Name, au.Kind, au.Count, au.Rate, au.Cause)
+	}
+	if len(report.Flagged) == 0 {
+		fmt.Printf("\nno isolated cpu received architecture-specific interrupts\n")
+		return nil
+	}
+	fmt.Printf("\nisolated cpus receiving architecture-specific interrupts: %v\n", report.Flagged)
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -30,8 +31,16 @@ import (
 	"github.com/openshift-kni/debug-tools/pkg/procs"
 )
 
+const (
+	groupByPod       = "pod"
+	groupByContainer = "container"
+	groupByCgroup    = "cgroup"
+)
+
 type cpuAffOptions struct {
 	pidIdent string
+	policies string
+	groupBy  string
 }
 
 func NewCPUAffinityCommand(knitOpts *KnitOptions) *cobra.Command {
@@ -45,6 +54,8 @@ func NewCPUAffinityCommand(knitOpts *KnitOptions) *cobra.Command {
 		Args: cobra.NoArgs,
 	}
 	cpuAff.Flags().StringVarP(&opts.pidIdent, "pid", "p", "", "monitor only threads belonging to this pid (default is all).")
+	cpuAff.Flags().StringVar(&opts.policies, "policy", "", "show only threads with these comma-separated scheduling policies (e.g. fifo,rr). Default is all.")
+	cpuAff.Flags().StringVar(&opts.groupBy, "group-by", "", "group threads by owner. One of: pod, container, cgroup. Default is no grouping.")
 	return cpuAff
 }
 
@@ -54,6 +65,14 @@ type runnable struct {
 	ProcessName string `json:"process"`
 	ThreadName  string `json:"thread"`
 	CPUAffinity []int  `json:"affinity"`
+	State       string `json:"state,omitempty"`
+	Policy      string `json:"policy,omitempty"`
+	RTPriority  int    `json:"rtPriority"`
+	Nice        int    `json:"nice"`
+	Cgroup      string `json:"cgroup,omitempty"`
+	PodUID      string `json:"podUID,omitempty"`
+	ContainerID string `json:"containerID,omitempty"`
+	QOSClass    string `json:"qosClass,omitempty"`
 }
 
 func (ru runnable) String() string {
@@ -61,32 +80,39 @@ func (ru runnable) String() string {
 	// "The thread name is a meaningful C language string, whose length is restricted to 16 characters,
 	// including the terminating null byte"
 	// for process names howevwver we just pick a "usually long enough" format value
-	return fmt.Sprintf("PID %6d (%-32s) TID %6d (%-16s) can run on %v", ru.PID, ru.ProcessName, ru.TID, ru.ThreadName, ru.CPUAffinity)
+	return fmt.Sprintf("PID %6d (%-32s) TID %6d (%-16s) [%1s %-8s rtprio %2d nice %3d] can run on %v", ru.PID, ru.ProcessName, ru.TID, ru.ThreadName, ru.State, ru.Policy, ru.RTPriority, ru.Nice, ru.CPUAffinity)
 }
 
 func showCPUAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *cpuAffOptions, args []string) error {
 	ph := procs.New(knitOpts.Log, knitOpts.ProcFSRoot)
 
+	policies, err := parsePolicies(opts.policies)
+	if err != nil {
+		return err
+	}
+	groupKey, err := groupKeyFunc(opts.groupBy)
+	if err != nil {
+		return err
+	}
+
+	var procInfos map[int]procs.PIDInfo
 	if opts.pidIdent != "" {
 		pid, err := strconv.Atoi(opts.pidIdent)
 		if err != nil {
 			return fmt.Errorf("error parsing %q: %v", opts.pidIdent, err)
 		}
 		procInfo, err := ph.FromPID(pid)
-		for tid, tidInfo := range procInfo.TIDs {
-			threadCpus := cpuset.New(tidInfo.Affinity...)
-
-			cpus := threadCpus.Intersection(knitOpts.Cpus)
-			if cpus.Size() != 0 {
-				fmt.Printf("PID %6d TID %6d can run on %v\n", pid, tid, cpus.String())
-			}
+		if err != nil {
+			return fmt.Errorf("error getting process info for %d from %q: %v", pid, knitOpts.ProcFSRoot, err)
+		}
+		procInfos = map[int]procs.PIDInfo{
+			pid: procInfo,
+		}
+	} else {
+		procInfos, err = ph.ListAll()
+		if err != nil {
+			return fmt.Errorf("error getting process infos from %q: %v", knitOpts.ProcFSRoot, err)
 		}
-		return nil
-	}
-
-	procInfos, err := ph.ListAll()
-	if err != nil {
-		return fmt.Errorf("error getting process infos from %q: %v", knitOpts.ProcFSRoot, err)
 	}
 
 	var runnables []runnable
@@ -96,6 +122,10 @@ func showCPUAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *cpuAffOpti
 		for _, tid := range sortedTids(procInfo.TIDs) {
 			tidInfo := procInfo.TIDs[tid]
 
+			if len(policies) > 0 && !policies[tidInfo.Policy] {
+				continue
+			}
+
 			threadCpus := cpuset.New(tidInfo.Affinity...)
 			cpus := threadCpus.Intersection(knitOpts.Cpus)
 			if cpus.Size() == 0 {
@@ -107,10 +137,22 @@ func showCPUAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *cpuAffOpti
 				ProcessName: procInfo.Name,
 				ThreadName:  tidInfo.Name,
 				CPUAffinity: cpus.List(),
+				State:       tidInfo.State,
+				Policy:      tidInfo.Policy,
+				RTPriority:  tidInfo.RTPriority,
+				Nice:        tidInfo.Nice,
+				Cgroup:      procInfo.Cgroup,
+				PodUID:      procInfo.PodUID,
+				ContainerID: procInfo.ContainerID,
+				QOSClass:    procInfo.QOSClass,
 			})
 		}
 	}
 
+	if groupKey != nil {
+		return showRunnableGroups(knitOpts, opts.groupBy, groupRunnables(runnables, groupKey))
+	}
+
 	if knitOpts.JsonOutput {
 		json.NewEncoder(os.Stdout).Encode(runnables)
 	} else {
@@ -121,6 +163,101 @@ func showCPUAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *cpuAffOpti
 	return nil
 }
 
+type runnableGroup struct {
+	Key       string     `json:"key"`
+	QOSClass  string     `json:"qosClass,omitempty"`
+	Runnables []runnable `json:"threads"`
+}
+
+func groupKeyFunc(groupBy string) (func(ru runnable) string, error) {
+	switch groupBy {
+	case "":
+		return nil, nil
+	case groupByPod:
+		return func(ru runnable) string { return ru.PodUID }, nil
+	case groupByContainer:
+		return func(ru runnable) string { return ru.ContainerID }, nil
+	case groupByCgroup:
+		return func(ru runnable) string { return ru.Cgroup }, nil
+	}
+	return nil, fmt.Errorf("unsupported group-by value %q", groupBy)
+}
+
+// groupRunnables returns the groups sorted by key. Threads not belonging to any group
+// (e.g. not running in a pod) are collected in the last group, which has empty key.
+func groupRunnables(runnables []runnable, groupKey func(ru runnable) string) []runnableGroup {
+	groups := make(map[string]*runnableGroup)
+	for _, ru := range runnables {
+		key := groupKey(ru)
+		grp, ok := groups[key]
+		if !ok {
+			grp = &runnableGroup{
+				Key:      key,
+				QOSClass: ru.QOSClass,
+			}
+			groups[key] = grp
+		}
+		grp.Runnables = append(grp.Runnables, ru)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		if key == "" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if _, ok := groups[""]; ok {
+		keys = append(keys, "")
+	}
+
+	res := make([]runnableGroup, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, *groups[key])
+	}
+	return res
+}
+
+func showRunnableGroups(knitOpts *KnitOptions, groupBy string, groups []runnableGroup) error {
+	if knitOpts.JsonOutput {
+		json.NewEncoder(os.Stdout).Encode(groups)
+		return nil
+	}
+	for idx, grp := range groups {
+		if idx > 0 {
+			fmt.Println()
+		}
+		switch {
+		case grp.Key == "":
+			fmt.Printf("no %s:\n", groupBy)
+		case grp.QOSClass != "" && groupBy != groupByCgroup:
+			fmt.Printf("%s %s (%s):\n", groupBy, grp.Key, grp.QOSClass)
+		default:
+			fmt.Printf("%s %s:\n", groupBy, grp.Key)
+		}
+		for _, runnable := range grp.Runnables {
+			fmt.Println(runnable.String())
+		}
+	}
+	return nil
+}
+
+func parsePolicies(policyList string) (map[string]bool, error) {
+	policies := make(map[string]bool)
+	if policyList == "" {
+		return policies, nil
+	}
+	for _, item := range strings.Split(policyList, ",") {
+		policy, err := procs.ParsePolicy(item)
+		if err != nil {
+			return nil, err
+		}
+		policies[policy] = true
+	}
+	return policies, nil
+}
+
 func sortedPids(procInfos map[int]procs.PIDInfo) []int {
 	pids := make([]int, len(procInfos))
 	for pid := range procInfos {
@@ -0,0 +1,114 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-kni/debug-tools/pkg/procs"
+	"github.com/openshift-kni/debug-tools/pkg/procs/occupancy"
+)
+
+type cpuOccOptions struct {
+	period  string
+	maxRuns int
+	verbose int
+	ownPIDs string
+}
+
+func NewCPUOccupancyCommand(knitOpts *KnitOptions) *cobra.Command {
+	opts := &cpuOccOptions{}
+	cpuOcc := &cobra.Command{
+		Use:   "cpuocc",
+		Short: "watch which threads actually consume time on the selected cpus",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return watchCPUOccupancy(cmd, knitOpts, opts, args)
+		},
+		Args: cobra.NoArgs,
+	}
+	cpuOcc.Flags().IntVarP(&opts.maxRuns, "watch-times", "T", -1, "number of watch loops to perform, each every `watch-period`. Use -1 to run forever.")
+	cpuOcc.Flags().StringVarP(&opts.period, "watch-period", "W", "1s", "period to sample thread accounting.")
+	cpuOcc.Flags().IntVarP(&opts.verbose, "verbose", "v", 1, "verbosiness amount.")
+	cpuOcc.Flags().StringVarP(&opts.ownPIDs, "pid", "p", "", "comma-separated pids of the workload owning the cpus. Their threads are not reported.")
+	return cpuOcc
+}
+
+func watchCPUOccupancy(cmd *cobra.Command, knitOpts *KnitOptions, opts *cpuOccOptions, args []string) error {
+	if opts.maxRuns == 0 {
+		return nil
+	}
+
+	var err error
+	period, err := time.ParseDuration(opts.period)
+	if err != nil {
+		return err
+	}
+	ownPIDs, err := parsePIDList(opts.ownPIDs)
+	if err != nil {
+		return err
+	}
+
+	ph := procs.New(knitOpts.Log, knitOpts.ProcFSRoot)
+
+	initTs := time.Now()
+	prevSamples, err := ph.SampleAll()
+	if err != nil {
+		return err
+	}
+
+	reporter := occupancy.NewReporter(os.Stdout, knitOpts.JsonOutput, opts.verbose, knitOpts.Cpus)
+	total := make(occupancy.Stats)
+
+	err = WatchLoop(period, opts.maxRuns, func(t time.Time) error {
+		lastSamples, err := ph.SampleAll()
+		if err != nil {
+			return err
+		}
+		delta := occupancy.Compute(prevSamples, lastSamples, knitOpts.Cpus, ownPIDs)
+		total.Add(delta)
+		reporter.Delta(t, delta)
+		prevSamples = lastSamples
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	reporter.Summary(initTs, total)
+	return nil
+}
+
+func parsePIDList(pidList string) (map[int]bool, error) {
+	pids := make(map[int]bool)
+	if pidList == "" {
+		return pids, nil
+	}
+	for _, item := range strings.Split(pidList, ",") {
+		pid, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", item, err)
+		}
+		pids[pid] = true
+	}
+	return pids, nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-kni/debug-tools/pkg/cpustat"
+)
+
+type cpuStatOptions struct {
+	period  string
+	maxRuns int
+	verbose int
+}
+
+func NewCPUStatCommand(knitOpts *KnitOptions) *cobra.Command {
+	opts := &cpuStatOptions{}
+	cpuStat := &cobra.Command{
+		Use:   "cpustat",
+		Short: "watch how the selected cpus spend their time",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return watchCPUStat(cmd, knitOpts, opts, args)
+		},
+		Args: cobra.NoArgs,
+	}
+	cpuStat.Flags().IntVarP(&opts.maxRuns, "watch-times", "T", -1, "number of watch loops to perform, each every `watch-period`. Use -1 to run forever.")
+	cpuStat.Flags().StringVarP(&opts.period, "watch-period", "W", "1s", "period to poll the cpu time accounting.")
+	cpuStat.Flags().IntVarP(&opts.verbose, "verbose", "v", 1, "verbosiness amount.")
+	return cpuStat
+}
+
+func watchCPUStat(cmd *cobra.Command, knitOpts *KnitOptions, opts *cpuStatOptions, args []string) error {
+	if opts.maxRuns == 0 {
+		return nil
+	}
+
+	var err error
+	period, err := time.ParseDuration(opts.period)
+	if err != nil {
+		return err
+	}
+
+	ch := cpustat.New(knitOpts.Log, knitOpts.ProcFSRoot)
+
+	initTs := time.Now()
+	initStats, err := ch.ReadStats()
+	if err != nil {
+		return err
+	}
+
+	prevStats := initStats
+	lastStats := initStats
+	reporter := cpustat.NewReporter(os.Stdout, knitOpts.JsonOutput, opts.verbose, knitOpts.Cpus)
+
+	err = WatchLoop(period, opts.maxRuns, func(t time.Time) error {
+		lastStats, err = ch.ReadStats()
+		if err != nil {
+			return err
+		}
+		reporter.Delta(t, prevStats, lastStats)
+		prevStats = lastStats
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	reporter.Summary(initTs, initStats, lastStats)
+	return nil
+}
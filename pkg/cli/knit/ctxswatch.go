@@ -0,0 +1,110 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-kni/debug-tools/pkg/procs"
+	"github.com/openshift-kni/debug-tools/pkg/procs/ctxswitch"
+)
+
+type ctxSwWatchOptions struct {
+	period        string
+	maxRuns       int
+	verbose       int
+	pid           int
+	busyThreshold float64
+}
+
+func NewCtxSwitchWatchCommand(knitOpts *KnitOptions) *cobra.Command {
+	opts := &ctxSwWatchOptions{}
+	ctxsWatch := &cobra.Command{
+		Use:   "ctxswatch",
+		Short: "watch the context switches of the threads of a process",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return watchCtxSwitches(cmd, knitOpts, opts, args)
+		},
+		Args: cobra.NoArgs,
+	}
+	ctxsWatch.Flags().IntVarP(&opts.maxRuns, "watch-times", "T", -1, "number of watch loops to perform, each every `watch-period`. Use -1 to run forever.")
+	ctxsWatch.Flags().StringVarP(&opts.period, "watch-period", "W", "1s", "period to poll the context switch counters.")
+	ctxsWatch.Flags().IntVarP(&opts.verbose, "verbose", "v", 1, "verbosiness amount.")
+	ctxsWatch.Flags().IntVarP(&opts.pid, "pid", "p", 0, "pid of the process to watch.")
+	ctxsWatch.Flags().Float64Var(&opts.busyThreshold, "busy-threshold", 90.0, "percentage of cpu time above which a thread is considered busy polling.")
+	ctxsWatch.MarkFlagRequired("pid")
+	return ctxsWatch
+}
+
+func watchCtxSwitches(cmd *cobra.Command, knitOpts *KnitOptions, opts *ctxSwWatchOptions, args []string) error {
+	if opts.maxRuns == 0 {
+		return nil
+	}
+	if opts.pid <= 0 {
+		return fmt.Errorf("invalid pid %d", opts.pid)
+	}
+
+	var err error
+	period, err := time.ParseDuration(opts.period)
+	if err != nil {
+		return err
+	}
+
+	ph := procs.New(knitOpts.Log, knitOpts.ProcFSRoot)
+
+	initTs := time.Now()
+	initSample, err := sampleCtxSwitches(ph, opts.pid, initTs)
+	if err != nil {
+		return err
+	}
+
+	prevSample := initSample
+	lastSample := initSample
+	reporter := ctxswitch.NewReporter(os.Stdout, knitOpts.JsonOutput, opts.verbose, opts.busyThreshold)
+
+	err = WatchLoop(period, opts.maxRuns, func(t time.Time) error {
+		lastSample, err = sampleCtxSwitches(ph, opts.pid, t)
+		if err != nil {
+			return err
+		}
+		reporter.Delta(t, prevSample, lastSample)
+		prevSample = lastSample
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	reporter.Summary(initTs, initSample, lastSample)
+	return nil
+}
+
+func sampleCtxSwitches(ph *procs.Handler, pid int, ts time.Time) (ctxswitch.Sample, error) {
+	pidInfo, err := ph.FromPID(pid)
+	if err != nil {
+		return ctxswitch.Sample{}, fmt.Errorf("error getting process info for %d: %v", pid, err)
+	}
+	tasks, err := ph.SamplePID(pid)
+	if err != nil {
+		return ctxswitch.Sample{}, fmt.Errorf("error sampling process %d: %v", pid, err)
+	}
+	return ctxswitch.NewSample(ts, pidInfo, tasks), nil
+}
@@ -21,16 +21,31 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"sort"
 
 	"github.com/spf13/cobra"
 
 	"github.com/openshift-kni/debug-tools/pkg/cli/knit"
+	ethtoolext "github.com/openshift-kni/debug-tools/pkg/ethtool"
+	"github.com/openshift-kni/debug-tools/pkg/netns"
 	goethtool "github.com/safchain/ethtool"
 )
 
 type ethtoolOptions struct {
 	showFeatures bool
 	showChannels bool
+	showDriver   bool
+	showRings    bool
+	showCoalesce bool
+	showPause    bool
+	showStats    bool
+	showSteering bool
+	planSteering bool
+	watch        bool
+	allStats     bool
+	period       string
+	maxRuns      int
+	netNS        knit.NetNSOptions
 }
 
 func NewEthtoolCommand(knitOpts *knit.KnitOptions) *cobra.Command {
@@ -39,16 +54,37 @@ func NewEthtoolCommand(knitOpts *knit.KnitOptions) *cobra.Command {
 		Use:   "ethtool",
 		Short: "subset of ethtool query capabilities",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return showEthtool(cmd, knitOpts, opts, args)
+			nsPath, sysfsRoot, err := opts.netNS.Resolve(knitOpts)
+			if err != nil {
+				return err
+			}
+			// the ethtool sockets must be created in the namespace of the interfaces
+			return netns.Do(nsPath, func() error {
+				return showEthtool(cmd, knitOpts, opts, sysfsRoot, args)
+			})
 		},
 		Args: cobra.MaximumNArgs(1),
 	}
 	eInfo.Flags().BoolVarP(&opts.showFeatures, "show-features", "k", false, "show the features of the selected devices")
 	eInfo.Flags().BoolVarP(&opts.showChannels, "show-channels", "l", false, "show the channels of the selected devices")
+	eInfo.Flags().BoolVarP(&opts.showDriver, "driver", "i", false, "show the driver information of the selected devices")
+	eInfo.Flags().BoolVarP(&opts.showRings, "show-ring", "g", false, "show the rings of the selected devices")
+	eInfo.Flags().BoolVarP(&opts.showCoalesce, "show-coalesce", "c", false, "show the interrupt coalescing of the selected devices")
+	eInfo.Flags().BoolVarP(&opts.showPause, "show-pause", "a", false, "show the pause parameters of the selected devices")
+	// -S, like ethtool, would clash with the --sysfs shorthand
+	eInfo.Flags().BoolVar(&opts.showStats, "statistics", false, "show the NIC statistics of the selected devices")
+	eInfo.Flags().BoolVar(&opts.watch, "watch", false, "with --statistics, watch the NIC statistics reporting the counters which change. By default only the drops and the errors are reported.")
+	eInfo.Flags().BoolVar(&opts.allStats, "all-stats", false, "with --watch, report all the counters which change.")
+	eInfo.Flags().IntVarP(&opts.maxRuns, "watch-times", "T", -1, "number of watch loops to perform, each every `watch-period`. Use -1 to run forever.")
+	eInfo.Flags().StringVarP(&opts.period, "watch-period", "W", "1s", "period to poll the NIC statistics.")
+	eInfo.Flags().BoolVar(&opts.showSteering, "show-steering", false, "show the RPS and XPS cpus of the queues of the selected devices, highlighting the isolated cpus (--cpulist, or the cpus the kernel isolates)")
+	eInfo.Flags().BoolVar(&opts.planSteering, "plan-steering", false, "show the RPS cpumask writes needed to keep the packet processing off the isolated cpus (--cpulist, or the cpus the kernel isolates). Implies --show-steering")
+	opts.netNS.AddFlags(eInfo.Flags())
 	return eInfo
 }
 
-func showEthtool(cmd *cobra.Command, knitOpts *knit.KnitOptions, opts *ethtoolOptions, args []string) error {
+// showEthtool queries the interfaces in the current network namespace. `sysfsRoot` must show the same namespace.
+func showEthtool(cmd *cobra.Command, knitOpts *knit.KnitOptions, opts *ethtoolOptions, sysfsRoot string, args []string) error {
 	var err error
 	ifaces := args
 	if len(ifaces) == 0 {
@@ -58,6 +94,13 @@ func showEthtool(cmd *cobra.Command, knitOpts *knit.KnitOptions, opts *ethtoolOp
 		return err
 	}
 
+	if opts.watch {
+		if !opts.showStats {
+			return fmt.Errorf("--watch requires --statistics")
+		}
+		return watchEthtoolStats(knitOpts, opts, ifaces)
+	}
+
 	needSeparator := false
 	if len(ifaces) > 1 {
 		needSeparator = true
@@ -69,7 +112,18 @@ func showEthtool(cmd *cobra.Command, knitOpts *knit.KnitOptions, opts *ethtoolOp
 	}
 	defer ethHandle.Close()
 
+	extHandle, err := ethtoolext.New()
+	if err != nil {
+		return err
+	}
+	defer extHandle.Close()
+
 	for _, iface := range ifaces {
+		if opts.showDriver {
+			if err := showEthtoolDriver(ethHandle, iface, knitOpts.JsonOutput); err != nil {
+				return err
+			}
+		}
 		if opts.showFeatures {
 			if err := showEthtoolFeatures(ethHandle, iface, knitOpts.JsonOutput); err != nil {
 				return err
@@ -80,6 +134,31 @@ func showEthtool(cmd *cobra.Command, knitOpts *knit.KnitOptions, opts *ethtoolOp
 				return err
 			}
 		}
+		if opts.showRings {
+			if err := showEthtoolRings(extHandle, iface, knitOpts.JsonOutput); err != nil {
+				return err
+			}
+		}
+		if opts.showCoalesce {
+			if err := showEthtoolCoalesce(ethHandle, iface, knitOpts.JsonOutput); err != nil {
+				return err
+			}
+		}
+		if opts.showPause {
+			if err := showEthtoolPause(extHandle, iface, knitOpts.JsonOutput); err != nil {
+				return err
+			}
+		}
+		if opts.showStats {
+			if err := showEthtoolStats(ethHandle, iface, knitOpts.JsonOutput); err != nil {
+				return err
+			}
+		}
+		if opts.showSteering || opts.planSteering {
+			if err := showEthtoolSteering(knitOpts, sysfsRoot, iface, opts.planSteering); err != nil {
+				return err
+			}
+		}
 		if needSeparator {
 			fmt.Printf("\n")
 		}
@@ -126,6 +205,111 @@ func showEthtoolChannels(et *goethtool.Ethtool, iface string, jsonMode bool) err
 	return nil
 }
 
+func showEthtoolDriver(et *goethtool.Ethtool, iface string, jsonMode bool) error {
+	drvInfo, err := et.DriverInfo(iface)
+	if err != nil {
+		return fmt.Errorf("error getting the driver information of %q: %v", iface, err)
+	}
+	if jsonMode {
+		json.NewEncoder(os.Stdout).Encode(drvInfo)
+		return nil
+	}
+	fmt.Printf("driver: %s\n", drvInfo.Driver)
+	fmt.Printf("version: %s\n", drvInfo.Version)
+	fmt.Printf("firmware-version: %s\n", drvInfo.FwVersion)
+	fmt.Printf("expansion-rom-version: %s\n", drvInfo.EromVersion)
+	fmt.Printf("bus-info: %s\n", drvInfo.BusInfo)
+	return nil
+}
+
+func showEthtoolRings(eh *ethtoolext.Handle, iface string, jsonMode bool) error {
+	rings, err := eh.GetRings(iface)
+	if err != nil {
+		return fmt.Errorf("error getting the ring parameters of %q: %v", iface, err)
+	}
+	if jsonMode {
+		json.NewEncoder(os.Stdout).Encode(rings)
+		return nil
+	}
+	fmt.Printf("Ring parameters for %s:\n", iface)
+	fmt.Printf("Pre-set maximums:\n")
+	fmt.Printf("RX:		%d\n", rings.RxMaxPending)
+	fmt.Printf("RX Mini:	%d\n", rings.RxMiniMaxPending)
+	fmt.Printf("RX Jumbo:	%d\n", rings.RxJumboMaxPending)
+	fmt.Printf("TX:		%d\n", rings.TxMaxPending)
+	fmt.Printf("Current hardware settings:\n")
+	fmt.Printf("RX:		%d\n", rings.RxPending)
+	fmt.Printf("RX Mini:	%d\n", rings.RxMiniPending)
+	fmt.Printf("RX Jumbo:	%d\n", rings.RxJumboPending)
+	fmt.Printf("TX:		%d\n", rings.TxPending)
+	return nil
+}
+
+func showEthtoolCoalesce(et *goethtool.Ethtool, iface string, jsonMode bool) error {
+	coal, err := et.GetCoalesce(iface)
+	if err != nil {
+		return fmt.Errorf("error getting the coalesce parameters of %q: %v", iface, err)
+	}
+	if jsonMode {
+		json.NewEncoder(os.Stdout).Encode(coal)
+		return nil
+	}
+	fmt.Printf("Coalesce parameters for %s:\n", iface)
+	fmt.Printf("Adaptive RX: %s  TX: %s\n", toggle(coal.UseAdaptiveRxCoalesce != 0), toggle(coal.UseAdaptiveTxCoalesce != 0))
+	fmt.Printf("stats-block-usecs: %d\n", coal.StatsBlockCoalesceUsecs)
+	fmt.Printf("sample-interval: %d\n", coal.RateSampleInterval)
+	fmt.Printf("pkt-rate-low: %d\n", coal.PktRateLow)
+	fmt.Printf("pkt-rate-high: %d\n", coal.PktRateHigh)
+	fmt.Printf("\n")
+	fmt.Printf("rx-usecs: %d\n", coal.RxCoalesceUsecs)
+	fmt.Printf("rx-frames: %d\n", coal.RxMaxCoalescedFrames)
+	fmt.Printf("rx-usecs-irq: %d\n", coal.RxCoalesceUsecsIrq)
+	fmt.Printf("rx-frames-irq: %d\n", coal.RxMaxCoalescedFramesIrq)
+	fmt.Printf("\n")
+	fmt.Printf("tx-usecs: %d\n", coal.TxCoalesceUsecs)
+	fmt.Printf("tx-frames: %d\n", coal.TxMaxCoalescedFrames)
+	fmt.Printf("tx-usecs-irq: %d\n", coal.TxCoalesceUsecsIrq)
+	fmt.Printf("tx-frames-irq: %d\n", coal.TxMaxCoalescedFramesIrq)
+	return nil
+}
+
+func showEthtoolPause(eh *ethtoolext.Handle, iface string, jsonMode bool) error {
+	pause, err := eh.GetPause(iface)
+	if err != nil {
+		return fmt.Errorf("error getting the pause parameters of %q: %v", iface, err)
+	}
+	if jsonMode {
+		json.NewEncoder(os.Stdout).Encode(pause)
+		return nil
+	}
+	fmt.Printf("Pause parameters for %s:\n", iface)
+	fmt.Printf("Autonegotiate:	%s\n", toggle(pause.Autoneg != 0))
+	fmt.Printf("RX:		%s\n", toggle(pause.RxPause != 0))
+	fmt.Printf("TX:		%s\n", toggle(pause.TxPause != 0))
+	return nil
+}
+
+func showEthtoolStats(et *goethtool.Ethtool, iface string, jsonMode bool) error {
+	stats, err := et.Stats(iface)
+	if err != nil {
+		return fmt.Errorf("error getting the statistics of %q: %v", iface, err)
+	}
+	if jsonMode {
+		json.NewEncoder(os.Stdout).Encode(stats)
+		return nil
+	}
+	var names []string
+	for name := range stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Printf("NIC statistics for %s:\n", iface)
+	for _, name := range names {
+		fmt.Printf("     %s: %d\n", name, stats[name])
+	}
+	return nil
+}
+
 func toggle(v bool) string {
 	if v {
 		return "on"
@@ -0,0 +1,105 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package ethtool
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/openshift-kni/debug-tools/pkg/cli/knit"
+	ethtoolext "github.com/openshift-kni/debug-tools/pkg/ethtool"
+	goethtool "github.com/safchain/ethtool"
+)
+
+type statsEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Iface     string    `json:"iface"`
+	ethtoolext.StatDelta
+}
+
+func (ev statsEvent) String() string {
+	queue := "-"
+	if ev.Queue != ethtoolext.NoQueue {
+		queue = fmt.Sprintf("%d", ev.Queue)
+	}
+	return fmt.Sprintf("%v %-16s queue=%-3s %-32s +%d (%.2f/s)", ev.Timestamp, ev.Iface, queue, ev.Name, ev.Delta, ev.Rate)
+}
+
+func watchEthtoolStats(knitOpts *knit.KnitOptions, opts *ethtoolOptions, ifaces []string) error {
+	if opts.maxRuns == 0 {
+		return nil
+	}
+	period, err := time.ParseDuration(opts.period)
+	if err != nil {
+		return err
+	}
+
+	ethHandle, err := goethtool.NewEthtool()
+	if err != nil {
+		return err
+	}
+	defer ethHandle.Close()
+
+	prevTs := time.Now()
+	prevStats, err := readAllStats(ethHandle, ifaces)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+
+	return knit.WatchLoop(period, opts.maxRuns, func(t time.Time) error {
+		lastStats, err := readAllStats(ethHandle, ifaces)
+		if err != nil {
+			return err
+		}
+		for _, iface := range ifaces {
+			for _, sd := range ethtoolext.Delta(prevStats[iface], lastStats[iface], t.Sub(prevTs)) {
+				if !opts.allStats && !ethtoolext.IsProblem(sd.Name) {
+					continue
+				}
+				ev := statsEvent{
+					Timestamp: t,
+					Iface:     iface,
+					StatDelta: sd,
+				}
+				if knitOpts.JsonOutput {
+					enc.Encode(ev)
+				} else {
+					fmt.Println(ev.String())
+				}
+			}
+		}
+		prevStats = lastStats
+		prevTs = t
+		return nil
+	})
+}
+
+func readAllStats(et *goethtool.Ethtool, ifaces []string) (map[string]ethtoolext.Stats, error) {
+	res := make(map[string]ethtoolext.Stats)
+	for _, iface := range ifaces {
+		stats, err := et.Stats(iface)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the statistics of %q: %v", iface, err)
+		}
+		res[iface] = stats
+	}
+	return res, nil
+}
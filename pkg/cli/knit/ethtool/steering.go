@@ -0,0 +1,137 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package ethtool
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/cli/knit"
+	"github.com/openshift-kni/debug-tools/pkg/isolation"
+	"github.com/openshift-kni/debug-tools/pkg/netqueue"
+)
+
+type steeringQueue struct {
+	Queue string `json:"queue"`
+	CPUs  []int  `json:"cpus"`
+	// SelectedCPUs are the cpus in the queue mask which are also isolated
+	SelectedCPUs []int `json:"selectedCPUs"`
+	// FlowCount is rps_flow_cnt, rx queues only
+	FlowCount uint64 `json:"flowCount,omitempty"`
+	// RxQueues is xps_rxqs, tx queues only
+	RxQueues []int `json:"rxQueues,omitempty"`
+}
+
+type steeringReport struct {
+	Iface    string           `json:"iface"`
+	RxQueues []steeringQueue  `json:"rxQueues"`
+	TxQueues []steeringQueue  `json:"txQueues"`
+	Plan     []netqueue.Write `json:"plan,omitempty"`
+}
+
+func showEthtoolSteering(knitOpts *knit.KnitOptions, sysfsRoot, iface string, plan bool) error {
+	nh := netqueue.New(knitOpts.Log, sysfsRoot)
+	info, err := nh.ReadInfo(iface)
+	if err != nil {
+		return fmt.Errorf("error reading the queues of %q: %v", iface, err)
+	}
+
+	isolInfo, err := isolation.New(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.SysFSRoot).ReadInfo()
+	if err != nil {
+		return err
+	}
+	isolated, _ := knit.IsolatedCPUs(knitOpts, isolInfo)
+	if plan && isolated.Size() == 0 {
+		return fmt.Errorf("no isolated cpus to keep the packet processing off, use --cpulist to select them")
+	}
+
+	report := steeringReport{
+		Iface: iface,
+	}
+	for _, rxq := range info.RxQueues {
+		report.RxQueues = append(report.RxQueues, steeringQueue{
+			Queue:        rxq.Name(),
+			CPUs:         rxq.RPSCPUs.List(),
+			SelectedCPUs: rxq.RPSCPUs.Intersection(isolated).List(),
+			FlowCount:    rxq.RPSFlowCount,
+		})
+	}
+	for _, txq := range info.TxQueues {
+		sq := steeringQueue{
+			Queue: txq.Name(),
+		}
+		if txq.XPSCPUs != nil {
+			sq.CPUs = txq.XPSCPUs.List()
+			sq.SelectedCPUs = txq.XPSCPUs.Intersection(isolated).List()
+		}
+		if txq.XPSRxQueues != nil {
+			sq.RxQueues = txq.XPSRxQueues.List()
+		}
+		report.TxQueues = append(report.TxQueues, sq)
+	}
+	if plan {
+		report.Plan = nh.Plan(info, isolated)
+	}
+
+	if knitOpts.JsonOutput {
+		json.NewEncoder(os.Stdout).Encode(report)
+		return nil
+	}
+	fmt.Printf("Packet steering for %s:\n", iface)
+	for _, sq := range report.RxQueues {
+		line := fmt.Sprintf("%-8s rps_cpus %-16s rps_flow_cnt %-6d%s", sq.Queue, formatCPUs(sq.CPUs), sq.FlowCount, formatSelected(sq.SelectedCPUs))
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	for idx, sq := range report.TxQueues {
+		xpsCPUs := "N/A"
+		if info.TxQueues[idx].XPSCPUs != nil {
+			xpsCPUs = formatCPUs(sq.CPUs)
+		}
+		xpsRxQueues := "N/A"
+		if info.TxQueues[idx].XPSRxQueues != nil {
+			xpsRxQueues = formatCPUs(sq.RxQueues)
+		}
+		line := fmt.Sprintf("%-8s xps_cpus %-16s xps_rxqs %-16s%s", sq.Queue, xpsCPUs, xpsRxQueues, formatSelected(sq.SelectedCPUs))
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	if plan {
+		fmt.Printf("Packet steering plan for %s:\n", iface)
+		for _, wr := range report.Plan {
+			fmt.Println(wr.String())
+		}
+	}
+	return nil
+}
+
+// formatCPUs returns the cpulist, or "-" if there are no cpus, which means steering is disabled
+func formatCPUs(cpus []int) string {
+	if len(cpus) == 0 {
+		return "-"
+	}
+	return cpuset.New(cpus...).String()
+}
+
+func formatSelected(cpus []int) string {
+	if len(cpus) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" on selected cpus %v", cpuset.New(cpus...))
+}
@@ -25,6 +25,9 @@ import (
 
 	"github.com/openshift-kni/debug-tools/pkg/irqs"
 	softirqs "github.com/openshift-kni/debug-tools/pkg/irqs/soft"
+	"github.com/openshift-kni/debug-tools/pkg/netns"
+	"github.com/openshift-kni/debug-tools/pkg/pcidev"
+	goethtool "github.com/safchain/ethtool"
 	cpuset "k8s.io/utils/cpuset"
 )
 
@@ -32,6 +35,14 @@ type irqAffOptions struct {
 	checkEffective  bool
 	checkSoftirqs   bool
 	showEmptySource bool
+	device          string
+	iface           string
+	explain         bool
+	probeManaged    bool
+	watch           bool
+	period          string
+	maxRuns         int
+	netNS           NetNSOptions
 }
 
 func NewIRQAffinityCommand(knitOpts *KnitOptions) *cobra.Command {
@@ -40,8 +51,15 @@ func NewIRQAffinityCommand(knitOpts *KnitOptions) *cobra.Command {
 		Use:   "irqaff",
 		Short: "show IRQ/softirq thread affinities",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := resolveIfaceDevice(knitOpts, opts); err != nil {
+				return err
+			}
 			if opts.checkSoftirqs {
 				return showSoftIRQAffinity(cmd, knitOpts, opts, args)
+			} else if opts.watch {
+				return watchIRQAffinity(cmd, knitOpts, opts, args)
+			} else if opts.explain {
+				return explainIRQAffinity(cmd, knitOpts, opts, args)
 			} else {
 				return showIRQAffinity(cmd, knitOpts, opts, args)
 			}
@@ -51,17 +69,71 @@ func NewIRQAffinityCommand(knitOpts *KnitOptions) *cobra.Command {
 	irqAff.Flags().BoolVarP(&opts.checkEffective, "effective-affinity", "E", false, "check effective affinity.")
 	irqAff.Flags().BoolVarP(&opts.checkSoftirqs, "softirqs", "s", false, "check softirqs counters.")
 	irqAff.Flags().BoolVarP(&opts.showEmptySource, "show-empty-source", "e", false, "show infos if IRQ source is not reported.")
+	irqAff.Flags().StringVar(&opts.device, "device", "", "show only IRQs belonging to this PCI device (e.g. 0000:3b:00.0).")
+	irqAff.Flags().StringVar(&opts.iface, "iface", "", "show only IRQs belonging to the device backing this network interface. Use --netns or --pid for the interfaces in other network namespaces.")
+	opts.netNS.AddFlags(irqAff.Flags())
+	irqAff.AddCommand(
+		NewIRQPlanCommand(knitOpts),
+		NewIRQRollbackCommand(knitOpts),
+	)
+	irqAff.Flags().BoolVar(&opts.watch, "watch", false, "watch for changes of the IRQ affinities, reporting which is the likely writer.")
+	irqAff.Flags().IntVarP(&opts.maxRuns, "watch-times", "T", -1, "number of watch loops to perform, each every `watch-period`. Use -1 to run forever.")
+	irqAff.Flags().StringVarP(&opts.period, "watch-period", "W", "1s", "period to check the IRQ affinities.")
+	irqAff.Flags().BoolVar(&opts.explain, "explain", false, "show both the requested and the effective affinity, and flag the IRQs reaching the isolated cpus which cannot be moved. Managed IRQs are detected using debugfs, if mounted.")
+	irqAff.Flags().BoolVar(&opts.probeManaged, "probe-managed", false, "with --explain, detect the managed IRQs writing back the current affinity if debugfs is not mounted. The write sets the affinity again, and may change the effective affinity.")
 	return irqAff
 }
 
+// resolveIfaceDevice finds the PCI device backing the interface in another network namespace,
+// which the host sysfs does not show, asking its bus info to the driver.
+func resolveIfaceDevice(knitOpts *KnitOptions, opts *irqAffOptions) error {
+	nsPath, _, err := opts.netNS.Resolve(knitOpts)
+	if err != nil || nsPath == "" || opts.iface == "" {
+		return err
+	}
+	var busInfo string
+	err = netns.Do(nsPath, func() error {
+		var err error
+		busInfo, err = goethtool.BusInfo(opts.iface)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error getting the bus info of %q in %q: %v", opts.iface, nsPath, err)
+	}
+	if busInfo == "" {
+		return fmt.Errorf("interface %q in %q is not backed by a PCI device", opts.iface, nsPath)
+	}
+	knitOpts.Log.Printf("interface %q in %q is backed by %s", opts.iface, nsPath, busInfo)
+	opts.device = busInfo
+	opts.iface = ""
+	return nil
+}
+
+type irqDevice struct {
+	Address  string `json:"address"`
+	Driver   string `json:"driver,omitempty"`
+	NUMANode int    `json:"numaNode"`
+	Iface    string `json:"iface,omitempty"`
+	Queue    string `json:"queue,omitempty"`
+}
+
 type irqAffinity struct {
-	IRQ         int    `json:"irq"`
-	Source      string `json:"source"`
-	CPUAffinity []int  `json:"affinity"`
+	IRQ         int        `json:"irq"`
+	Source      string     `json:"source"`
+	CPUAffinity []int      `json:"affinity"`
+	Device      *irqDevice `json:"device,omitempty"`
+	irqs.Description
 }
 
 func (ia irqAffinity) String() string {
-	return fmt.Sprintf("IRQ %3d [%24s]: can run on %v", ia.IRQ, ia.Source, ia.CPUAffinity)
+	if ia.Device == nil {
+		return fmt.Sprintf("IRQ %3d [%24s]: can run on %v", ia.IRQ, ia.Source, ia.CPUAffinity)
+	}
+	dev := ia.Device
+	if dev.Iface == "" {
+		return fmt.Sprintf("IRQ %3d -> %s (%s, NUMA %d) can run on %v", ia.IRQ, ia.Source, dev.Address, dev.NUMANode, ia.CPUAffinity)
+	}
+	return fmt.Sprintf("IRQ %3d -> %s queue %s (%s, NUMA %d) can run on %v", ia.IRQ, dev.Iface, dev.Queue, dev.Address, dev.NUMANode, ia.CPUAffinity)
 }
 
 type softirqAffinity struct {
@@ -86,6 +158,12 @@ func showIRQAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqAffOpti
 		return fmt.Errorf("error parsing irqs from %q: %v", knitOpts.ProcFSRoot, err)
 	}
 
+	devs, selected, err := selectIRQDevices(knitOpts, opts)
+	if err != nil {
+		return err
+	}
+	irqOwners := devs.IRQOwners()
+
 	var irqAffinities []irqAffinity
 	for _, irqInfo := range irqInfos {
 		cpus := irqInfo.CPUs.Intersection(knitOpts.Cpus)
@@ -95,11 +173,20 @@ func showIRQAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqAffOpti
 		if irqInfo.Source == "" && !opts.showEmptySource {
 			continue
 		}
-		irqAffinities = append(irqAffinities, irqAffinity{
+		addr, owned := irqOwners[irqInfo.IRQ]
+		if selected != nil && !selected[addr] {
+			continue
+		}
+		ia := irqAffinity{
 			IRQ:         irqInfo.IRQ,
 			Source:      irqInfo.Source,
 			CPUAffinity: cpus.List(),
-		})
+			Description: irqInfo.Description,
+		}
+		if owned {
+			ia.Device = newIRQDevice(devs[addr], irqInfo.Source)
+		}
+		irqAffinities = append(irqAffinities, ia)
 	}
 
 	if knitOpts.JsonOutput {
@@ -112,6 +199,45 @@ func showIRQAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqAffOpti
 	return nil
 }
 
+// selectIRQDevices reads the PCI devices, and the addresses of the ones matching --device and --iface.
+// The selection is nil if there is no filter.
+func selectIRQDevices(knitOpts *KnitOptions, opts *irqAffOptions) (pcidev.Devices, map[string]bool, error) {
+	devs, err := pcidev.New(knitOpts.Log, knitOpts.SysFSRoot).ReadDevices()
+	if err != nil {
+		if opts.device != "" || opts.iface != "" {
+			return nil, nil, fmt.Errorf("error reading PCI devices from %q: %v", knitOpts.SysFSRoot, err)
+		}
+		// failures are not critical, we just can't tell the devices
+		knitOpts.Log.Printf("Error reading PCI devices from %q: %v", knitOpts.SysFSRoot, err)
+	}
+	if opts.device == "" && opts.iface == "" {
+		return devs, nil, nil
+	}
+	matching := devs.Find(opts.device, opts.iface)
+	if len(matching) == 0 {
+		return nil, nil, fmt.Errorf("no PCI device matching device=%q iface=%q", opts.device, opts.iface)
+	}
+	selected := make(map[string]bool)
+	for _, dev := range matching {
+		selected[dev.Address] = true
+	}
+	return devs, selected, nil
+}
+
+func newIRQDevice(dev pcidev.Device, action string) *irqDevice {
+	iface := dev.IfaceFor(action)
+	irqDev := &irqDevice{
+		Address:  dev.Address,
+		Driver:   dev.Driver,
+		NUMANode: dev.NUMANode,
+		Iface:    iface,
+	}
+	if iface != "" {
+		irqDev.Queue = pcidev.QueueName(action, iface)
+	}
+	return irqDev
+}
+
 func showSoftIRQAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqAffOptions, args []string) error {
 	sh := softirqs.New(knitOpts.Log, knitOpts.ProcFSRoot)
 	info, err := sh.ReadInfo()
@@ -126,9 +252,8 @@ func showSoftIRQAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqAff
 		counters := info.Counters[key]
 		var cb []int
 		for idx, counter := range counters {
-			if counter > 0 {
-				cb = append(cb, idx)
-
+			if counter > 0 && idx < len(info.CPUIDs) {
+				cb = append(cb, info.CPUIDs[idx])
 			}
 		}
 		usedCPUs := knitOpts.Cpus.Intersection(cpuset.New(cb...))
@@ -0,0 +1,113 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/irqbalance"
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
+)
+
+type irqAffinityEvent struct {
+	irqs.AffinityChange
+	LikelyWriter string `json:"likelyWriter"`
+}
+
+func (ev irqAffinityEvent) String() string {
+	return fmt.Sprintf("%s (likely writer: %s)", ev.AffinityChange.String(), ev.LikelyWriter)
+}
+
+func watchIRQAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqAffOptions, args []string) error {
+	if opts.maxRuns == 0 {
+		return nil
+	}
+	period, err := time.ParseDuration(opts.period)
+	if err != nil {
+		return err
+	}
+
+	ih := irqs.New(knitOpts.Log, knitOpts.ProcFSRoot)
+	ibh := irqbalance.New(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.RootFSRoot)
+
+	prevInfos, err := ih.ReadInfo(0)
+	if err != nil {
+		return fmt.Errorf("error parsing irqs from %q: %v", knitOpts.ProcFSRoot, err)
+	}
+	if !knitOpts.JsonOutput {
+		ibInfo, err := ibh.ReadInfo()
+		if err != nil {
+			return fmt.Errorf("error checking irqbalance: %v", err)
+		}
+		fmt.Println(describeIRQBalance(ibInfo))
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+
+	return WatchLoop(period, opts.maxRuns, func(t time.Time) error {
+		lastInfos, err := ih.ReadInfo(0)
+		if err != nil {
+			return fmt.Errorf("error parsing irqs from %q: %v", knitOpts.ProcFSRoot, err)
+		}
+		changes := irqs.DiffAffinity(t, prevInfos, lastInfos)
+		prevInfos = lastInfos
+		if len(changes) == 0 {
+			return nil
+		}
+
+		// the daemon can be started or stopped anytime, so we need to check again
+		ibInfo, err := ibh.ReadInfo()
+		if err != nil {
+			return fmt.Errorf("error checking irqbalance: %v", err)
+		}
+		for _, change := range changes {
+			to, err := cpuset.Parse(change.To)
+			if err != nil {
+				return err
+			}
+			ev := irqAffinityEvent{
+				AffinityChange: change,
+				LikelyWriter:   ibInfo.LikelyWriter(to),
+			}
+			if knitOpts.JsonOutput {
+				enc.Encode(ev)
+			} else {
+				fmt.Println(ev.String())
+			}
+		}
+		return nil
+	})
+}
+
+func describeIRQBalance(info irqbalance.Info) string {
+	if !info.Running() {
+		return "irqbalance is not running"
+	}
+	st := info.Effective()
+	desc := fmt.Sprintf("irqbalance is running (pids %v) banned cpus %v", info.PIDs, st.Banned())
+	if info.Runtime != nil && info.Config != nil && !info.Runtime.Banned().Equals(info.Config.Banned()) {
+		desc += fmt.Sprintf(", but /%s bans cpus %v: restart irqbalance to apply", info.ConfigPath, info.Config.Banned())
+	}
+	return desc
+}
@@ -0,0 +1,85 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-kni/debug-tools/pkg/irqbalance"
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
+	"github.com/openshift-kni/debug-tools/pkg/isolation"
+)
+
+type irqBalanceOptions struct {
+	detectManaged bool
+}
+
+func NewIRQBalanceCommand(knitOpts *KnitOptions) *cobra.Command {
+	opts := &irqBalanceOptions{}
+	irqBalance := &cobra.Command{
+		Use:   "irqbalance",
+		Short: "audit the irqbalance configuration against the isolated cpus and the IRQ affinities",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showIRQBalance(cmd, knitOpts, opts, args)
+		},
+		Args: cobra.NoArgs,
+	}
+	irqBalance.Flags().BoolVar(&opts.detectManaged, "detect-managed", false, "skip the kernel-managed IRQs, which irqbalance cannot move. Detecting managed IRQs without debugfs requires to write back the current affinity.")
+	return irqBalance
+}
+
+func showIRQBalance(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqBalanceOptions, args []string) error {
+	info, err := isolation.New(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.SysFSRoot).ReadInfo()
+	if err != nil {
+		return err
+	}
+	isolated, _ := IsolatedCPUs(knitOpts, info)
+
+	ibInfo, err := irqbalance.New(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.RootFSRoot).ReadInfo()
+	if err != nil {
+		return fmt.Errorf("error checking irqbalance: %v", err)
+	}
+
+	flags := uint(0)
+	if opts.detectManaged {
+		flags |= irqs.DetectManaged | irqs.ProbeManaged
+	}
+	irqInfos, err := irqs.NewWithSysFS(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.SysFSRoot).ReadInfo(flags)
+	if err != nil {
+		return fmt.Errorf("error parsing irqs from %q: %v", knitOpts.ProcFSRoot, err)
+	}
+
+	findings := irqbalance.Check(ibInfo, isolated, irqInfos)
+
+	if knitOpts.JsonOutput {
+		json.NewEncoder(os.Stdout).Encode(findings)
+		return nil
+	}
+	fmt.Println(describeIRQBalance(ibInfo))
+	if len(findings) == 0 {
+		fmt.Println("no inconsistencies found")
+		return nil
+	}
+	for _, finding := range findings {
+		fmt.Println(finding.String())
+	}
+	return nil
+}
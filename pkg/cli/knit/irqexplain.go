@@ -0,0 +1,138 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
+)
+
+const (
+	// the effective affinity does not reach the isolated cpus
+	irqVerdictOK = "ok"
+	// the effective affinity reaches the isolated cpus, but can be changed
+	irqVerdictMovable = "movable"
+	// the effective affinity reaches the isolated cpus, and cannot be changed from userspace
+	irqVerdictUnmovable = "unmovable"
+	// the effective affinity reaches the isolated cpus, and we can't tell if it can be changed
+	irqVerdictUnknown = "unknown"
+)
+
+type irqExplanation struct {
+	IRQ               int    `json:"irq"`
+	Source            string `json:"source"`
+	Affinity          []int  `json:"affinity"`
+	EffectiveAffinity []int  `json:"effectiveAffinity"`
+	Managed           bool   `json:"managed"`
+	Unmovable         bool   `json:"unmovable"`
+	ManagedCheck      string `json:"managedCheck,omitempty"`
+	// IsolatedEffective are the isolated cpus the IRQ is actually served on
+	IsolatedEffective []int  `json:"isolatedEffective,omitempty"`
+	Verdict           string `json:"verdict"`
+}
+
+func (ie irqExplanation) String() string {
+	managed := "managed=unknown"
+	switch ie.ManagedCheck {
+	case irqs.ManagedCheckDebugFS:
+		managed = fmt.Sprintf("managed=%v (%s)", ie.Managed, ie.ManagedCheck)
+	case irqs.ManagedCheckProbe:
+		// the probe can't tell managed IRQs from the other unmovable ones
+		managed = fmt.Sprintf("managed=unknown settable=%v (%s)", !ie.Unmovable, ie.ManagedCheck)
+	}
+	var reason string
+	switch ie.Verdict {
+	case irqVerdictOK:
+		reason = "effective affinity does not reach the isolated cpus"
+	case irqVerdictMovable:
+		reason = fmt.Sprintf("effective affinity reaches isolated cpus %v, can be moved", ie.IsolatedEffective)
+	case irqVerdictUnmovable:
+		reason = fmt.Sprintf("effective affinity reaches isolated cpus %v and cannot be moved", ie.IsolatedEffective)
+	default:
+		reason = fmt.Sprintf("effective affinity reaches isolated cpus %v, cannot tell if it can be moved", ie.IsolatedEffective)
+	}
+	return fmt.Sprintf("IRQ %3d [%24s]: affinity %v effective %v %s: %s", ie.IRQ, ie.Source, ie.Affinity, ie.EffectiveAffinity, managed, reason)
+}
+
+func explainIRQAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqAffOptions, args []string) error {
+	ih := irqs.NewWithSysFS(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.SysFSRoot)
+
+	flags := uint(irqs.DetectManaged)
+	if opts.probeManaged {
+		flags |= irqs.ProbeManaged
+	}
+	irqInfos, err := ih.ReadInfo(flags)
+	if err != nil {
+		return fmt.Errorf("error parsing irqs from %q: %v", knitOpts.ProcFSRoot, err)
+	}
+
+	devs, selected, err := selectIRQDevices(knitOpts, opts)
+	if err != nil {
+		return err
+	}
+	irqOwners := devs.IRQOwners()
+
+	var explanations []irqExplanation
+	for _, irqInfo := range irqInfos {
+		if selected != nil && !selected[irqOwners[irqInfo.IRQ]] {
+			continue
+		}
+		if irqInfo.AffinityCPUs.Union(irqInfo.EffectiveCPUs).Intersection(knitOpts.Cpus).Size() == 0 {
+			continue
+		}
+		if irqInfo.Source == "" && !opts.showEmptySource {
+			continue
+		}
+		isolatedEffective := irqInfo.EffectiveCPUs.Intersection(knitOpts.Cpus)
+		ie := irqExplanation{
+			IRQ:               irqInfo.IRQ,
+			Source:            irqInfo.Source,
+			Affinity:          irqInfo.AffinityCPUs.List(),
+			EffectiveAffinity: irqInfo.EffectiveCPUs.List(),
+			Managed:           irqInfo.Managed,
+			Unmovable:         irqInfo.Unmovable,
+			ManagedCheck:      irqInfo.ManagedCheck,
+			Verdict:           irqVerdictOK,
+		}
+		if isolatedEffective.Size() > 0 {
+			ie.IsolatedEffective = isolatedEffective.List()
+			switch {
+			case irqInfo.ManagedCheck == "":
+				ie.Verdict = irqVerdictUnknown
+			case irqInfo.Unmovable:
+				ie.Verdict = irqVerdictUnmovable
+			default:
+				ie.Verdict = irqVerdictMovable
+			}
+		}
+		explanations = append(explanations, ie)
+	}
+
+	if knitOpts.JsonOutput {
+		json.NewEncoder(os.Stdout).Encode(explanations)
+	} else {
+		for _, ie := range explanations {
+			fmt.Println(ie.String())
+		}
+	}
+	return nil
+}
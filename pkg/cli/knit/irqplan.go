@@ -0,0 +1,233 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
+)
+
+const defaultIRQJournal = "irqaff-journal.jsonl"
+
+type irqPlanOptions struct {
+	housekeeping string
+	apply        bool
+	journal      string
+}
+
+func NewIRQPlanCommand(knitOpts *KnitOptions) *cobra.Command {
+	opts := &irqPlanOptions{}
+	plan := &cobra.Command{
+		Use:   "plan",
+		Short: "plan (and optionally apply) the IRQ affinity changes to move the IRQs on the housekeeping cpus",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return planIRQAffinity(cmd, knitOpts, opts, args)
+		},
+		Args: cobra.NoArgs,
+	}
+	plan.Flags().StringVar(&opts.housekeeping, "housekeeping", "", "housekeeping cpu set which should serve the IRQs (see man (7) cpuset - List format).")
+	plan.Flags().BoolVar(&opts.apply, "apply", false, "apply the planned changes. Default is to just show them.")
+	plan.Flags().StringVar(&opts.journal, "journal", defaultIRQJournal, "journal file recording the applied changes, to roll them back.")
+	plan.MarkFlagRequired("housekeeping")
+	return plan
+}
+
+type irqRollbackOptions struct {
+	apply   bool
+	journal string
+}
+
+func NewIRQRollbackCommand(knitOpts *KnitOptions) *cobra.Command {
+	opts := &irqRollbackOptions{}
+	rollback := &cobra.Command{
+		Use:   "rollback",
+		Short: "roll back (optionally applying) the IRQ affinity changes recorded in the journal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return rollbackIRQAffinity(cmd, knitOpts, opts, args)
+		},
+		Args: cobra.NoArgs,
+	}
+	rollback.Flags().BoolVar(&opts.apply, "apply", false, "apply the rollback. Default is to just show the changes.")
+	rollback.Flags().StringVar(&opts.journal, "journal", defaultIRQJournal, "journal file recording the applied changes.")
+	return rollback
+}
+
+type irqPlan struct {
+	Changes []irqs.Change  `json:"changes"`
+	Skipped []irqs.Skipped `json:"skipped,omitempty"`
+	// ManagedUnknown are the IRQs to change whose managed status could not be detected
+	ManagedUnknown []int        `json:"managedUnknown,omitempty"`
+	Applied        bool         `json:"applied"`
+	Failed         []irqFailure `json:"failed,omitempty"`
+}
+
+type irqFailure struct {
+	irqs.Change
+	Error string `json:"error"`
+}
+
+func planIRQAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqPlanOptions, args []string) error {
+	housekeeping, err := cpuset.Parse(opts.housekeeping)
+	if err != nil {
+		return fmt.Errorf("error parsing %q: %v", opts.housekeeping, err)
+	}
+	if housekeeping.Size() == 0 {
+		return fmt.Errorf("empty housekeeping cpu set")
+	}
+
+	// never probe: the probe writes the affinity of every IRQ, and those writes are not journaled.
+	// Without debugfs, the changes of the unmovable IRQs are skipped when applied.
+	ih := irqs.NewWithSysFS(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.SysFSRoot)
+	irqInfos, err := ih.ReadInfo(irqs.DetectManaged)
+	if err != nil {
+		return fmt.Errorf("error parsing irqs from %q: %v", knitOpts.ProcFSRoot, err)
+	}
+
+	plan := irqPlan{}
+	plan.Changes, plan.Skipped = irqs.Plan(irqInfos, housekeeping)
+	unchecked := make(map[int]bool)
+	for _, irqInfo := range irqInfos {
+		if irqInfo.ManagedCheck == "" {
+			unchecked[irqInfo.IRQ] = true
+		}
+	}
+	for _, ch := range plan.Changes {
+		if unchecked[ch.IRQ] {
+			plan.ManagedUnknown = append(plan.ManagedUnknown, ch.IRQ)
+		}
+	}
+	if opts.apply {
+		err = applyIRQChanges(ih, &plan, opts.journal)
+		if err != nil {
+			return err
+		}
+		plan.Applied = true
+	}
+	return showIRQPlan(knitOpts, plan)
+}
+
+func rollbackIRQAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqRollbackOptions, args []string) error {
+	src, err := os.Open(opts.journal)
+	if err != nil {
+		return fmt.Errorf("error opening the journal %q: %v", opts.journal, err)
+	}
+	entries, err := irqs.ReadJournal(src)
+	src.Close()
+	if err != nil {
+		return fmt.Errorf("error reading the journal %q: %v", opts.journal, err)
+	}
+
+	ih := irqs.New(knitOpts.Log, knitOpts.ProcFSRoot)
+	plan := irqPlan{
+		Changes: irqs.Rollback(entries),
+	}
+	if opts.apply {
+		err = applyIRQChanges(ih, &plan, "")
+		if err != nil {
+			return err
+		}
+		plan.Applied = true
+		if len(plan.Failed) == 0 && len(plan.Skipped) == 0 {
+			// everything is rolled back, so a later rollback must not undo again
+			if err := os.Remove(opts.journal); err != nil {
+				return fmt.Errorf("error removing the journal %q: %v", opts.journal, err)
+			}
+		}
+	}
+	return showIRQPlan(knitOpts, plan)
+}
+
+// applyIRQChanges applies the changes of the plan, recording the successful ones in the journal, unless `journal` is empty.
+// The changes the kernel rejects with EIO, because the IRQ affinity cannot be set from userspace, are added to the
+// skipped ones and removed from the changes. The changes which fail otherwise are added to the failed ones.
+func applyIRQChanges(ih *irqs.Handler, plan *irqPlan, journal string) error {
+	var dst *os.File
+	if journal != "" {
+		var err error
+		dst, err = os.OpenFile(journal, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("error opening the journal %q: %v", journal, err)
+		}
+		defer dst.Close()
+	}
+
+	changes := plan.Changes
+	plan.Changes = nil
+	for _, ch := range changes {
+		err := ih.Apply(ch)
+		if errors.Is(err, syscall.EIO) {
+			plan.Skipped = append(plan.Skipped, irqs.Skipped{
+				IRQ:    ch.IRQ,
+				Source: ch.Source,
+				Reason: "affinity not settable",
+			})
+			continue
+		}
+		plan.Changes = append(plan.Changes, ch)
+		if err != nil {
+			plan.Failed = append(plan.Failed, irqFailure{
+				Change: ch,
+				Error:  err.Error(),
+			})
+			continue
+		}
+		if dst == nil {
+			continue
+		}
+		entry := irqs.JournalEntry{
+			Timestamp: time.Now(),
+			Change:    ch,
+		}
+		if err := irqs.WriteJournalEntry(dst, entry); err != nil {
+			return fmt.Errorf("error writing the journal %q: %v", journal, err)
+		}
+	}
+	return nil
+}
+
+func showIRQPlan(knitOpts *KnitOptions, plan irqPlan) error {
+	if knitOpts.JsonOutput {
+		json.NewEncoder(os.Stdout).Encode(plan)
+		return nil
+	}
+	for _, ch := range plan.Changes {
+		fmt.Println(ch.String())
+	}
+	for _, sk := range plan.Skipped {
+		fmt.Printf("IRQ %3d [%24s]: skipped: %s\n", sk.IRQ, sk.Source, sk.Reason)
+	}
+	if len(plan.ManagedUnknown) > 0 {
+		fmt.Printf("managed status unknown for IRQs %v: debugfs is not available, the changes of the unmovable ones will be skipped\n", plan.ManagedUnknown)
+	}
+	for _, fl := range plan.Failed {
+		fmt.Printf("IRQ %3d [%24s]: failed: %s\n", fl.IRQ, fl.Source, fl.Error)
+	}
+	if plan.Applied {
+		fmt.Printf("applied %d changes\n", len(plan.Changes)-len(plan.Failed))
+	}
+	return nil
+}
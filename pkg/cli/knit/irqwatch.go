@@ -17,6 +17,7 @@
 package knit
 
 import (
+	"fmt"
 	"os"
 	"os/signal"
 	"time"
@@ -24,19 +25,29 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift-kni/debug-tools/pkg/irqs"
+	softirqs "github.com/openshift-kni/debug-tools/pkg/irqs/soft"
+	"github.com/openshift-kni/debug-tools/pkg/pcidev"
 )
 
 type irqWatchOptions struct {
-	period  string
-	maxRuns int
-	verbose int
+	period        string
+	maxRuns       int
+	verbose       int
+	watchSoftirqs bool
+	maxPerCPU     uint64
+	rateLimits    []string
+	allowed       []string
+	groupBy       string
+	top           int
+	record        string
+	replay        string
 }
 
 func NewIRQWatchCommand(knitOpts *KnitOptions) *cobra.Command {
 	opts := &irqWatchOptions{}
 	irqWatch := &cobra.Command{
 		Use:   "irqwatch",
-		Short: "watch IRQ counters",
+		Short: "watch IRQ/softirq counters",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return watchIRQs(cmd, knitOpts, opts, args)
 		},
@@ -45,6 +56,14 @@ func NewIRQWatchCommand(knitOpts *KnitOptions) *cobra.Command {
 	irqWatch.Flags().IntVarP(&opts.maxRuns, "watch-times", "T", -1, "number of watch loops to perform, each every `watch-period`. Use -1 to run forever.")
 	irqWatch.Flags().StringVarP(&opts.period, "watch-period", "W", "1s", "period to poll IRQ counters.")
 	irqWatch.Flags().IntVarP(&opts.verbose, "verbose", "v", 1, "verbosiness amount.")
+	irqWatch.Flags().BoolVarP(&opts.watchSoftirqs, "softirqs", "s", false, "watch softirqs counters, reporting also the rates.")
+	irqWatch.Flags().Uint64Var(&opts.maxPerCPU, "max-per-cpu", 0, "fail if any cpu serves more than this amount of interrupts in total. Use 0 for no limit.")
+	irqWatch.Flags().StringSliceVar(&opts.rateLimits, "rate-limit", nil, "fail if any cpu serves the named interrupts faster than the limit, like LOC<=1000/s. Can be repeated.")
+	irqWatch.Flags().StringSliceVar(&opts.allowed, "allow", nil, "fail if any cpu serves interrupts not in this comma-separated list of names.")
+	irqWatch.Flags().StringVar(&opts.groupBy, "group-by", "", "aggregate the counters. One of: cpu, irq. Default is no grouping.")
+	irqWatch.Flags().IntVar(&opts.top, "top", 0, "report only the top N counters by count. Use 0 to report all.")
+	irqWatch.Flags().StringVar(&opts.record, "record", "", "record the raw counters of every sample in this file, in the JSON lines format.")
+	irqWatch.Flags().StringVar(&opts.replay, "replay", "", "replay the samples recorded in this file instead of watching the system.")
 	return irqWatch
 }
 
@@ -58,15 +77,70 @@ func watchIRQs(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqWatchOptions,
 	if err != nil {
 		return err
 	}
+	if err := irqs.ValidateGroupBy(opts.groupBy); err != nil {
+		return err
+	}
+
+	reporterOpts := irqs.ReporterOptions{
+		JSONOutput: knitOpts.JsonOutput,
+		Verbose:    opts.verbose,
+		CPUs:       knitOpts.Cpus,
+		GroupBy:    opts.groupBy,
+		Top:        opts.top,
+	}
+	reporterOpts.Rules, err = makeIRQRules(opts)
+	if err != nil {
+		return err
+	}
+	if opts.replay != "" {
+		return replayIRQs(opts.replay, reporterOpts)
+	}
 
 	var initStats irqs.Stats
 	var prevStats irqs.Stats
 	var lastStats irqs.Stats
 
 	ih := irqs.New(knitOpts.Log, knitOpts.ProcFSRoot)
+	readStats := ih.ReadStats
+	kind := irqs.SampleKindIRQs
+	if opts.watchSoftirqs {
+		readStats = softirqs.New(knitOpts.Log, knitOpts.ProcFSRoot).ReadStats
+		kind = irqs.SampleKindSoftirqs
+	} else {
+		reporterOpts.Sources = readIRQSources(knitOpts, ih)
+	}
+	setupReporterForKind(&reporterOpts, kind)
+
+	var recording *os.File
+	if opts.record != "" {
+		recording, err = os.Create(opts.record)
+		if err != nil {
+			return fmt.Errorf("error creating the recording %q: %v", opts.record, err)
+		}
+		defer recording.Close()
+	}
+	recordSample := func(sample irqs.Sample) error {
+		if recording == nil {
+			return nil
+		}
+		if err := irqs.WriteSample(recording, sample); err != nil {
+			return fmt.Errorf("error writing the recording %q: %v", opts.record, err)
+		}
+		return nil
+	}
 
 	initTs := time.Now()
-	initStats, err = ih.ReadStats()
+	initStats, err = readStats()
+	if err != nil {
+		return err
+	}
+	reporterOpts.StartTime = initTs
+	err = recordSample(irqs.Sample{
+		Timestamp: initTs,
+		Kind:      kind,
+		Stats:     initStats,
+		Sources:   reporterOpts.Sources,
+	})
 	if err != nil {
 		return err
 	}
@@ -76,7 +150,7 @@ func watchIRQs(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqWatchOptions,
 
 	prevStats = initStats.Clone()
 	ticker := time.NewTicker(period)
-	reporter := irqs.NewReporter(os.Stdout, knitOpts.JsonOutput, opts.verbose, knitOpts.Cpus)
+	reporter := irqs.NewReporterWithOptions(os.Stdout, reporterOpts)
 
 	done := false
 	iterCount := 1
@@ -85,7 +159,15 @@ func watchIRQs(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqWatchOptions,
 		case <-c:
 			done = true
 		case t := <-ticker.C:
-			lastStats, err = ih.ReadStats()
+			lastStats, err = readStats()
+			if err != nil {
+				return err
+			}
+			err = recordSample(irqs.Sample{
+				Timestamp: t,
+				Kind:      kind,
+				Stats:     lastStats,
+			})
 			if err != nil {
 				return err
 			}
@@ -102,6 +184,84 @@ func watchIRQs(cmd *cobra.Command, knitOpts *KnitOptions, opts *irqWatchOptions,
 		iterCount++
 	}
 
-	reporter.Summary(initTs, initStats, lastStats)
-	return nil
+	return reporter.Summary(initTs, initStats, lastStats)
+}
+
+// replayIRQs feeds the reporter with the recorded samples, as if they were read from the system.
+func replayIRQs(path string, reporterOpts irqs.ReporterOptions) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening the recording %q: %v", path, err)
+	}
+	defer src.Close()
+	samples, err := irqs.ReadSamples(src)
+	if err != nil {
+		return fmt.Errorf("error reading the recording %q: %v", path, err)
+	}
+	if len(samples) == 0 {
+		return fmt.Errorf("no samples recorded in %q", path)
+	}
+
+	initSample := samples[0]
+	setupReporterForKind(&reporterOpts, initSample.Kind)
+	reporterOpts.Sources = initSample.Sources
+	reporterOpts.StartTime = initSample.Timestamp
+	lastSample := initSample
+	reporterOpts.Now = func() time.Time {
+		return lastSample.Timestamp
+	}
+
+	reporter := irqs.NewReporterWithOptions(os.Stdout, reporterOpts)
+	for _, sample := range samples[1:] {
+		if sample.Kind != initSample.Kind {
+			return fmt.Errorf("mixed samples in %q: %q and %q", path, initSample.Kind, sample.Kind)
+		}
+		reporter.Delta(sample.Timestamp, lastSample.Stats, sample.Stats)
+		lastSample = sample
+	}
+	return reporter.Summary(initSample.Timestamp, initSample.Stats, lastSample.Stats)
+}
+
+func setupReporterForKind(reporterOpts *irqs.ReporterOptions, kind string) {
+	if kind != irqs.SampleKindSoftirqs {
+		return
+	}
+	reporterOpts.Label = "SOFTIRQ"
+	reporterOpts.Rates = true
+}
+
+// readIRQSources resolves the IRQ numbers to the action names and, if possible, to the PCI devices.
+// Failures are not critical, we just report less details.
+func readIRQSources(knitOpts *KnitOptions, ih *irqs.Handler) map[string]string {
+	_, descs, err := ih.ReadInterrupts()
+	if err != nil {
+		knitOpts.Log.Printf("Error reading the IRQ descriptions: %v", err)
+		return nil
+	}
+	var owners map[int]string
+	devs, err := pcidev.New(knitOpts.Log, knitOpts.SysFSRoot).ReadDevices()
+	if err != nil {
+		knitOpts.Log.Printf("Error reading the PCI devices: %v", err)
+	} else {
+		owners = devs.IRQOwners()
+	}
+	return irqs.Sources(descs, owners)
+}
+
+func makeIRQRules(opts *irqWatchOptions) (irqs.Rules, error) {
+	rules := irqs.Rules{
+		MaxPerCPU: opts.maxPerCPU,
+		Allowed:   opts.allowed,
+	}
+	for _, item := range opts.rateLimits {
+		name, limit, err := irqs.ParseRateLimit(item)
+		if err != nil {
+			return rules, fmt.Errorf("error parsing the rate limits: %v", err)
+		}
+		if rules.RateLimits == nil {
+			rules.RateLimits = make(map[string]float64)
+		}
+		rules.RateLimits[name] = limit
+	}
+	return rules, nil
 }
@@ -0,0 +1,78 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/isolation"
+)
+
+func NewIsolationCommand(knitOpts *KnitOptions) *cobra.Command {
+	isol := &cobra.Command{
+		Use:   "isolation",
+		Short: "audit the kernel CPU isolation configuration",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showIsolation(cmd, knitOpts, args)
+		},
+		Args: cobra.NoArgs,
+	}
+	return isol
+}
+
+func showIsolation(cmd *cobra.Command, knitOpts *KnitOptions, args []string) error {
+	ih := isolation.New(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.SysFSRoot)
+	info, err := ih.ReadInfo()
+	if err != nil {
+		return err
+	}
+
+	findings := isolation.Check(info, knitOpts.Cpus)
+
+	if knitOpts.JsonOutput {
+		json.NewEncoder(os.Stdout).Encode(findings)
+		return nil
+	}
+	if len(findings) == 0 {
+		fmt.Println("no inconsistencies found")
+		return nil
+	}
+	for _, finding := range findings {
+		fmt.Println(finding.String())
+	}
+	return nil
+}
+
+// IsolatedCPUs returns the isolated and the housekeeping cpus. Unless explicitly given,
+// the isolated cpus are the ones the kernel was told to isolate.
+func IsolatedCPUs(knitOpts *KnitOptions, info isolation.Info) (cpuset.CPUSet, cpuset.CPUSet) {
+	online := info.Online()
+	isolated := knitOpts.Cpus
+	if online.Size() == 0 || online.IsSubsetOf(isolated) {
+		isolated = info.Isolated().Union(info.NohzFull())
+	}
+	if online.Size() > 0 {
+		isolated = isolated.Intersection(online)
+	}
+	return isolated, online.Difference(isolated)
+}
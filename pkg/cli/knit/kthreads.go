@@ -0,0 +1,148 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/isolation"
+	"github.com/openshift-kni/debug-tools/pkg/procs"
+)
+
+func NewKThreadsCommand(knitOpts *KnitOptions) *cobra.Command {
+	kthreads := &cobra.Command{
+		Use:   "kthreads",
+		Short: "show the kernel threads which can run on the isolated cpus",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showKThreads(cmd, knitOpts, args)
+		},
+		Args: cobra.NoArgs,
+	}
+	return kthreads
+}
+
+type kthread struct {
+	PID         int    `json:"pid"`
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	CPU         int    `json:"cpu"`
+	CPUAffinity []int  `json:"affinity"`
+}
+
+type rcuOffloadThread struct {
+	kthread
+	// Pinned is true if the thread can run only on housekeeping cpus
+	Pinned bool `json:"pinned"`
+}
+
+type kthreadsReport struct {
+	Isolated     []int `json:"isolated"`
+	Housekeeping []int `json:"housekeeping"`
+	// Unbound are the kernel threads not bound to a cpu which can run on the isolated cpus
+	Unbound []kthread `json:"unbound"`
+	// RCUOffload are the rcuo threads serving the nohz_full cpus
+	RCUOffload []rcuOffloadThread `json:"rcuOffload"`
+	// MissingRCUOffload are the nohz_full cpus without rcuo threads
+	MissingRCUOffload []int `json:"missingRCUOffload,omitempty"`
+}
+
+func showKThreads(cmd *cobra.Command, knitOpts *KnitOptions, args []string) error {
+	ih := isolation.New(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.SysFSRoot)
+	info, err := ih.ReadInfo()
+	if err != nil {
+		return err
+	}
+	nohzFull := info.NohzFull()
+	isolated, housekeeping := IsolatedCPUs(knitOpts, info)
+
+	ph := procs.New(knitOpts.Log, knitOpts.ProcFSRoot)
+	procInfos, err := ph.ListAll()
+	if err != nil {
+		return fmt.Errorf("error getting process infos from %q: %v", knitOpts.ProcFSRoot, err)
+	}
+
+	report := kthreadsReport{
+		Isolated:     isolated.List(),
+		Housekeeping: housekeeping.List(),
+	}
+	rcuoCPUs := cpuset.New()
+	for _, pid := range sortedPids(procInfos) {
+		procInfo := procInfos[pid]
+		if procInfo.KThread == nil {
+			continue
+		}
+		tidInfo := procInfo.TIDs[pid]
+		kt := kthread{
+			PID:         pid,
+			Name:        tidInfo.Name,
+			Kind:        procInfo.KThread.Kind,
+			CPU:         procInfo.KThread.CPU,
+			CPUAffinity: tidInfo.Affinity,
+		}
+		cpus := cpuset.New(tidInfo.Affinity...)
+
+		switch kt.Kind {
+		case procs.KThreadPerCPU:
+			// expected to run on their own cpu
+			continue
+		case procs.KThreadRCUOffload:
+			if !nohzFull.Contains(kt.CPU) {
+				continue
+			}
+			rcuoCPUs = rcuoCPUs.Union(cpuset.New(kt.CPU))
+			report.RCUOffload = append(report.RCUOffload, rcuOffloadThread{
+				kthread: kt,
+				Pinned:  cpus.Size() > 0 && cpus.IsSubsetOf(housekeeping),
+			})
+		default:
+			if cpus.Intersection(isolated).Size() == 0 {
+				continue
+			}
+			report.Unbound = append(report.Unbound, kt)
+		}
+	}
+	report.MissingRCUOffload = nohzFull.Difference(rcuoCPUs).List()
+
+	if knitOpts.JsonOutput {
+		json.NewEncoder(os.Stdout).Encode(report)
+		return nil
+	}
+
+	fmt.Printf("isolated cpus %v housekeeping cpus %v\n", report.Isolated, report.Housekeeping)
+	fmt.Printf("\nunbound kernel threads which can run on isolated cpus:\n")
+	for _, kt := range report.Unbound {
+		fmt.Printf("PID %6d (%-24s) [%-7s] can run on %v\n", kt.PID, kt.Name, kt.Kind, kt.CPUAffinity)
+	}
+	fmt.Printf("\nrcu offload threads for nohz_full cpus %v:\n", nohzFull.List())
+	for _, rt := range report.RCUOffload {
+		status := "OK"
+		if !rt.Pinned {
+			status = "NOT PINNED TO HOUSEKEEPING"
+		}
+		fmt.Printf("PID %6d (%-24s) offloads cpu %3d can run on %v %s\n", rt.PID, rt.Name, rt.CPU, rt.CPUAffinity, status)
+	}
+	for _, cpu := range report.MissingRCUOffload {
+		fmt.Printf("cpu %d: no rcuo thread found, check rcu_nocbs\n", cpu)
+	}
+	return nil
+}
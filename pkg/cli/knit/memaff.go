@@ -0,0 +1,159 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/numa"
+	"github.com/openshift-kni/debug-tools/pkg/procs"
+)
+
+type memAffOptions struct {
+	pid int
+}
+
+func NewMemoryAffinityCommand(knitOpts *KnitOptions) *cobra.Command {
+	opts := &memAffOptions{}
+	memAff := &cobra.Command{
+		Use:   "memaff",
+		Short: "show the NUMA memory placement of processes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showMemoryAffinity(cmd, knitOpts, opts, args)
+		},
+		Args: cobra.NoArgs,
+	}
+	memAff.Flags().IntVarP(&opts.pid, "pid", "p", 0, "show only this pid (default is all).")
+	return memAff
+}
+
+type memPlacement struct {
+	PID         int    `json:"pid"`
+	ProcessName string `json:"process"`
+	PodUID      string `json:"podUID,omitempty"`
+	ContainerID string `json:"containerID,omitempty"`
+	// CPUs are the cpus the threads of the process can run on
+	CPUs []int `json:"cpus"`
+	// CPUNodes are the NUMA nodes owning the CPUs
+	CPUNodes []int `json:"cpuNodes"`
+	// MemsAllowed are the NUMA nodes the process can allocate memory on
+	MemsAllowed []int `json:"memsAllowed"`
+	// Memory is the memory actually backed by pages on each NUMA node
+	Memory procs.NUMAMaps `json:"memory"`
+	// Remote is true if any memory sits on a node not owning any of the CPUs
+	Remote bool `json:"remote"`
+}
+
+func (mp memPlacement) String() string {
+	return fmt.Sprintf("PID %6d (%-32s) cpus %v on nodes %v mems allowed on nodes %v", mp.PID, mp.ProcessName, mp.CPUs, mp.CPUNodes, mp.MemsAllowed)
+}
+
+func showMemoryAffinity(cmd *cobra.Command, knitOpts *KnitOptions, opts *memAffOptions, args []string) error {
+	nodeCPUs, err := numa.New(knitOpts.Log, knitOpts.SysFSRoot).ReadNodeCPUs()
+	if err != nil {
+		return fmt.Errorf("error getting the NUMA topology from %q: %v", knitOpts.SysFSRoot, err)
+	}
+
+	ph := procs.New(knitOpts.Log, knitOpts.ProcFSRoot)
+
+	var procInfos map[int]procs.PIDInfo
+	if opts.pid > 0 {
+		procInfo, err := ph.FromPID(opts.pid)
+		if err != nil {
+			return fmt.Errorf("error getting process info for %d from %q: %v", opts.pid, knitOpts.ProcFSRoot, err)
+		}
+		procInfos = map[int]procs.PIDInfo{
+			opts.pid: procInfo,
+		}
+	} else {
+		procInfos, err = ph.ListAll()
+		if err != nil {
+			return fmt.Errorf("error getting process infos from %q: %v", knitOpts.ProcFSRoot, err)
+		}
+	}
+
+	var placements []memPlacement
+	for _, pid := range sortedPids(procInfos) {
+		procInfo := procInfos[pid]
+
+		cpus := cpuset.New()
+		mems := cpuset.New()
+		for _, tidInfo := range procInfo.TIDs {
+			cpus = cpus.Union(cpuset.New(tidInfo.Affinity...))
+			mems = mems.Union(cpuset.New(tidInfo.MemsAllowed...))
+		}
+		if cpus.Intersection(knitOpts.Cpus).Size() == 0 {
+			continue
+		}
+
+		numaMaps, err := ph.ReadNUMAMaps(pid)
+		if err != nil {
+			if opts.pid > 0 {
+				return fmt.Errorf("error reading NUMA maps for %d from %q: %v", pid, knitOpts.ProcFSRoot, err)
+			}
+			// failures are not critical
+			knitOpts.Log.Printf("Error reading NUMA maps for pid %d: %v", pid, err)
+			continue
+		}
+		if len(numaMaps.Nodes()) == 0 {
+			// kernel threads
+			continue
+		}
+
+		mp := memPlacement{
+			PID:         pid,
+			ProcessName: procInfo.Name,
+			PodUID:      procInfo.PodUID,
+			ContainerID: procInfo.ContainerID,
+			CPUs:        cpus.List(),
+			CPUNodes:    nodeCPUs.NodesOf(cpus),
+			MemsAllowed: mems.List(),
+			Memory:      numaMaps,
+		}
+		cpuNodes := cpuset.New(mp.CPUNodes...)
+		for _, node := range numaMaps.Nodes() {
+			if !cpuNodes.Contains(node) {
+				mp.Remote = true
+			}
+		}
+		placements = append(placements, mp)
+	}
+
+	if knitOpts.JsonOutput {
+		json.NewEncoder(os.Stdout).Encode(placements)
+		return nil
+	}
+	for _, mp := range placements {
+		fmt.Println(mp.String())
+		cpuNodes := cpuset.New(mp.CPUNodes...)
+		for _, node := range mp.Memory.Nodes() {
+			mem := mp.Memory[node]
+			remote := ""
+			if !cpuNodes.Contains(node) {
+				remote = " REMOTE"
+			}
+			fmt.Printf("  node %d: anon %10d KiB file %10d KiB huge %10d KiB%s\n", node, mem.Anon/1024, mem.File/1024, mem.Huge/1024, remote)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/openshift-kni/debug-tools/pkg/netns"
+)
+
+// NetNSOptions select the network namespace the network-related commands inspect.
+type NetNSOptions struct {
+	Path string
+	PID  int
+}
+
+func (opts *NetNSOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&opts.Path, "netns", "", "network namespace to inspect, like /var/run/netns/NAME. The sysfs queries still use --sysfs.")
+	flags.IntVar(&opts.PID, "pid", 0, "inspect the network namespace of this process, like a pod process. The sysfs queries use the sysfs of the process.")
+}
+
+// Resolve returns the path of the network namespace to enter, empty for the current one,
+// and the sysfs root which shows the network interfaces of that namespace.
+func (opts *NetNSOptions) Resolve(knitOpts *KnitOptions) (string, string, error) {
+	if opts.Path != "" && opts.PID != 0 {
+		return "", "", fmt.Errorf("--netns and --pid are mutually exclusive")
+	}
+	if opts.PID < 0 {
+		return "", "", fmt.Errorf("invalid pid %d", opts.PID)
+	}
+	if opts.PID > 0 {
+		return netns.PathForPID(knitOpts.ProcFSRoot, opts.PID), netns.SysFSForPID(knitOpts.ProcFSRoot, opts.PID), nil
+	}
+	return opts.Path, knitOpts.SysFSRoot, nil
+}
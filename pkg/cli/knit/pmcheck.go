@@ -0,0 +1,199 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/isolation"
+	"github.com/openshift-kni/debug-tools/pkg/powermgmt"
+)
+
+type pmCheckOptions struct {
+	maxLatencyUs uint64
+	watch        bool
+	period       string
+	maxRuns      int
+}
+
+func NewPMCheckCommand(knitOpts *KnitOptions) *cobra.Command {
+	opts := &pmCheckOptions{}
+	pmCheck := &cobra.Command{
+		Use:   "pmcheck",
+		Short: "audit the cpufreq, cpuidle and thermal throttling state of the cpus",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.watch {
+				return watchPM(cmd, knitOpts, opts, args)
+			}
+			return showPM(cmd, knitOpts, opts, args)
+		},
+		Args: cobra.NoArgs,
+	}
+	pmCheck.Flags().Uint64Var(&opts.maxLatencyUs, "max-exit-latency", 10, "flag the enabled idle states with exit latency above this value, in microseconds.")
+	pmCheck.Flags().BoolVar(&opts.watch, "watch", false, "watch the idle state residency and the thermal throttle events.")
+	pmCheck.Flags().IntVarP(&opts.maxRuns, "watch-times", "T", -1, "number of watch loops to perform, each every `watch-period`. Use -1 to run forever.")
+	pmCheck.Flags().StringVarP(&opts.period, "watch-period", "W", "1s", "period to sample the idle and throttle counters.")
+	return pmCheck
+}
+
+type pmReport struct {
+	CPUs     []powermgmt.CPUInfo `json:"cpus"`
+	Findings []isolation.Finding `json:"findings"`
+}
+
+func showPM(cmd *cobra.Command, knitOpts *KnitOptions, opts *pmCheckOptions, args []string) error {
+	info, err := isolation.New(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.SysFSRoot).ReadInfo()
+	if err != nil {
+		return err
+	}
+	isolated, _ := IsolatedCPUs(knitOpts, info)
+
+	cpuInfos, err := powermgmt.New(knitOpts.Log, knitOpts.SysFSRoot).ReadInfo(onlineCPUs(knitOpts, info))
+	if err != nil {
+		return fmt.Errorf("error reading the cpus from %q: %v", knitOpts.SysFSRoot, err)
+	}
+	findings := powermgmt.Check(cpuInfos, isolated, opts.maxLatencyUs)
+
+	if knitOpts.JsonOutput {
+		json.NewEncoder(os.Stdout).Encode(pmReport{
+			CPUs:     cpuInfos,
+			Findings: findings,
+		})
+		return nil
+	}
+	for _, ci := range cpuInfos {
+		fmt.Println(describeCPUPM(ci))
+	}
+	fmt.Println()
+	if len(findings) == 0 {
+		fmt.Println("no inconsistencies found")
+		return nil
+	}
+	for _, finding := range findings {
+		fmt.Println(finding.String())
+	}
+	return nil
+}
+
+func watchPM(cmd *cobra.Command, knitOpts *KnitOptions, opts *pmCheckOptions, args []string) error {
+	if opts.maxRuns == 0 {
+		return nil
+	}
+	period, err := time.ParseDuration(opts.period)
+	if err != nil {
+		return err
+	}
+
+	info, err := isolation.New(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.SysFSRoot).ReadInfo()
+	if err != nil {
+		return err
+	}
+	cpus := onlineCPUs(knitOpts, info)
+
+	ph := powermgmt.New(knitOpts.Log, knitOpts.SysFSRoot)
+	prevTs := time.Now()
+	prevInfos, err := ph.ReadInfo(cpus)
+	if err != nil {
+		return fmt.Errorf("error reading the cpus from %q: %v", knitOpts.SysFSRoot, err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+
+	return WatchLoop(period, opts.maxRuns, func(t time.Time) error {
+		lastInfos, err := ph.ReadInfo(cpus)
+		if err != nil {
+			return fmt.Errorf("error reading the cpus from %q: %v", knitOpts.SysFSRoot, err)
+		}
+		for _, cd := range powermgmt.Delta(prevInfos, lastInfos, t.Sub(prevTs)) {
+			if knitOpts.JsonOutput {
+				enc.Encode(pmDelta{
+					Timestamp: t,
+					CPUDelta:  cd,
+				})
+			} else {
+				fmt.Printf("%v %s\n", t, describeCPUPMDelta(cd))
+			}
+		}
+		prevInfos = lastInfos
+		prevTs = t
+		return nil
+	})
+}
+
+// onlineCPUs returns the selected cpus which are online, because sysfs lists also the possible cpus
+func onlineCPUs(knitOpts *KnitOptions, info isolation.Info) cpuset.CPUSet {
+	online := info.Online()
+	if online.Size() == 0 {
+		return knitOpts.Cpus
+	}
+	return knitOpts.Cpus.Intersection(online)
+}
+
+type pmDelta struct {
+	Timestamp time.Time `json:"timestamp"`
+	powermgmt.CPUDelta
+}
+
+func describeCPUPM(ci powermgmt.CPUInfo) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "CPU=%d", ci.CPU)
+	if ci.Freq == nil {
+		sb.WriteString(" cpufreq=N/A")
+	} else {
+		fmt.Fprintf(&sb, " governor=%s freq=%d-%dMHz cur=%dMHz", ci.Freq.Governor, ci.Freq.MinKHz/1000, ci.Freq.MaxKHz/1000, ci.Freq.CurKHz/1000)
+		if ci.Freq.EnergyPerfPreference != "" {
+			fmt.Fprintf(&sb, " epp=%s", ci.Freq.EnergyPerfPreference)
+		}
+	}
+	if len(ci.IdleStates) == 0 {
+		sb.WriteString(" cpuidle=N/A")
+	} else {
+		sb.WriteString(" idle=")
+		for idx, st := range ci.IdleStates {
+			if idx > 0 {
+				sb.WriteString(",")
+			}
+			fmt.Fprintf(&sb, "%s(%dus", st.Name, st.LatencyUs)
+			if st.Disabled {
+				sb.WriteString(" disabled")
+			}
+			sb.WriteString(")")
+		}
+	}
+	if ci.Throttle != nil {
+		fmt.Fprintf(&sb, " throttled=%d/%dms", ci.Throttle.CoreCount+ci.Throttle.PackageCount, ci.Throttle.CoreTimeMs+ci.Throttle.PackageTimeMs)
+	}
+	return sb.String()
+}
+
+func describeCPUPMDelta(cd powermgmt.CPUDelta) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "CPU=%d", cd.CPU)
+	for _, sd := range cd.IdleStates {
+		fmt.Fprintf(&sb, " %s=%5.1f%% (+%d)", sd.Name, sd.Residency, sd.Usage)
+	}
+	fmt.Fprintf(&sb, " throttled=+%d/%dms", cd.ThrottleEvents, cd.ThrottleTimeMs)
+	return sb.String()
+}
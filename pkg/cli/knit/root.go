@@ -31,6 +31,7 @@ type KnitOptions struct {
 	Cpus       cpuset.CPUSet
 	ProcFSRoot string
 	SysFSRoot  string
+	RootFSRoot string
 	JsonOutput bool
 	Debug      bool
 	Log        *log.Logger
@@ -77,6 +78,7 @@ func NewRootCommand(extraCmds ...NewCommandFunc) *cobra.Command {
 	root.PersistentFlags().StringVarP(&knitOpts.cpuList, "cpulist", "C", "0-16383", "isolated cpu set to check (see man (7) cpuset - List format")
 	root.PersistentFlags().StringVarP(&knitOpts.ProcFSRoot, "procfs", "P", "/proc", "procfs root")
 	root.PersistentFlags().StringVarP(&knitOpts.SysFSRoot, "sysfs", "S", "/sys", "sysfs root")
+	root.PersistentFlags().StringVar(&knitOpts.RootFSRoot, "rootfs", "/", "root filesystem, to find the configuration files")
 	root.PersistentFlags().BoolVarP(&knitOpts.Debug, "debug", "D", false, "enable debug log")
 	root.PersistentFlags().BoolVarP(&knitOpts.JsonOutput, "json", "J", false, "output as JSON")
 
@@ -86,6 +88,17 @@ func NewRootCommand(extraCmds ...NewCommandFunc) *cobra.Command {
 		NewIRQWatchCommand(knitOpts),
 		NewWaitCommand(knitOpts),
 		NewCtrreschkCommand(knitOpts),
+		NewIsolationCommand(knitOpts),
+		NewCPUOccupancyCommand(knitOpts),
+		NewCtxSwitchWatchCommand(knitOpts),
+		NewMemoryAffinityCommand(knitOpts),
+		NewKThreadsCommand(knitOpts),
+		NewArchIRQsCommand(knitOpts),
+		NewIRQBalanceCommand(knitOpts),
+		NewCPUStatCommand(knitOpts),
+		NewPMCheckCommand(knitOpts),
+		NewWorkqueuesCommand(knitOpts),
+		NewSRIOVCommand(knitOpts),
 	)
 	for _, extraCmd := range extraCmds {
 		root.AddCommand(extraCmd(knitOpts))
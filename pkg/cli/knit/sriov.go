@@ -0,0 +1,150 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/isolation"
+	"github.com/openshift-kni/debug-tools/pkg/numa"
+	"github.com/openshift-kni/debug-tools/pkg/sriov"
+)
+
+type sriovOptions struct {
+	pf string
+}
+
+func NewSRIOVCommand(knitOpts *KnitOptions) *cobra.Command {
+	opts := &sriovOptions{}
+	sriovCmd := &cobra.Command{
+		Use:   "sriov",
+		Short: "show the SR-IOV physical and virtual functions and their NUMA locality",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showSRIOV(cmd, knitOpts, opts, args)
+		},
+		Args: cobra.NoArgs,
+	}
+	sriovCmd.Flags().StringVar(&opts.pf, "pf", "", "show only the PF with this interface name or PCI address (default is all).")
+	return sriovCmd
+}
+
+type sriovReport struct {
+	Isolated []int `json:"isolated"`
+	// IsolatedNodes are the NUMA nodes owning the isolated cpus
+	IsolatedNodes []int      `json:"isolatedNodes"`
+	PFs           []sriov.PF `json:"pfs"`
+	// NUMAMismatch are the addresses of the VFs on a NUMA node not owning any isolated cpu
+	NUMAMismatch []string `json:"numaMismatch,omitempty"`
+}
+
+func showSRIOV(cmd *cobra.Command, knitOpts *KnitOptions, opts *sriovOptions, args []string) error {
+	info, err := isolation.New(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.SysFSRoot).ReadInfo()
+	if err != nil {
+		return err
+	}
+	isolated, _ := IsolatedCPUs(knitOpts, info)
+
+	nodeCPUs, err := numa.New(knitOpts.Log, knitOpts.SysFSRoot).ReadNodeCPUs()
+	if err != nil {
+		return fmt.Errorf("error getting the NUMA topology from %q: %v", knitOpts.SysFSRoot, err)
+	}
+
+	pfs, err := sriov.New(knitOpts.Log, knitOpts.SysFSRoot).ReadPFs()
+	if err != nil {
+		return fmt.Errorf("error reading the SR-IOV devices from %q: %v", knitOpts.SysFSRoot, err)
+	}
+
+	report := sriovReport{
+		Isolated:      isolated.List(),
+		IsolatedNodes: nodeCPUs.NodesOf(isolated),
+		PFs:           []sriov.PF{},
+	}
+	for _, pf := range pfs {
+		if opts.pf != "" && !pf.Matches(opts.pf) {
+			continue
+		}
+		report.PFs = append(report.PFs, pf)
+	}
+	if opts.pf != "" && len(report.PFs) == 0 {
+		return fmt.Errorf("no SR-IOV capable device matching %q", opts.pf)
+	}
+
+	// the VFs serving the isolated workloads should be local to them, unless we don't know
+	isolatedNodes := cpuset.New(report.IsolatedNodes...)
+	mismatch := make(map[string]bool)
+	for _, pf := range report.PFs {
+		for _, vf := range pf.VFs {
+			if isolatedNodes.Size() == 0 || vf.NUMANode < 0 || isolatedNodes.Contains(vf.NUMANode) {
+				continue
+			}
+			mismatch[vf.Address] = true
+			report.NUMAMismatch = append(report.NUMAMismatch, vf.Address)
+		}
+	}
+
+	if knitOpts.JsonOutput {
+		json.NewEncoder(os.Stdout).Encode(report)
+		return nil
+	}
+	if isolated.Size() > 0 {
+		fmt.Printf("isolated cpus %s on NUMA nodes %v\n", isolated.String(), report.IsolatedNodes)
+	}
+	for _, pf := range report.PFs {
+		fmt.Println(describePF(pf))
+		for _, vf := range pf.VFs {
+			desc := describeVF(vf)
+			if mismatch[vf.Address] {
+				desc += " NUMA MISMATCH"
+			}
+			fmt.Println("  " + desc)
+		}
+	}
+	return nil
+}
+
+func describePF(pf sriov.PF) string {
+	return fmt.Sprintf("PF %s %v driver %s NUMA node %d VFs %d/%d", pf.Address, pf.Ifaces, valueOr(pf.Driver, "none"), pf.NUMANode, pf.NumVFs, pf.TotalVFs)
+}
+
+func describeVF(vf sriov.VF) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "VF %3d %s driver %-10s NUMA node %d", vf.Index, vf.Address, valueOr(vf.Driver, "none"), vf.NUMANode)
+	if vf.Iface != "" {
+		fmt.Fprintf(&sb, " iface %s", vf.Iface)
+	}
+	if vf.MAC != "" {
+		fmt.Fprintf(&sb, " MAC %s", vf.MAC)
+	}
+	if vf.VLAN != "" {
+		fmt.Fprintf(&sb, " VLAN %s", vf.VLAN)
+	}
+	return sb.String()
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,57 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"os"
+	"os/signal"
+	"time"
+)
+
+// WatchLoop is the -W/-T loop of irqwatch: it calls `sample` every `period`, until it ran `maxRuns`
+// times (forever if `maxRuns` is not positive) or the user interrupts it. The interruption is not
+// an error, so the caller can report its summary. Errors from `sample` stop the loop and are returned.
+func WatchLoop(period time.Duration, maxRuns int, sample func(t time.Time) error) error {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+	defer signal.Stop(c)
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	done := false
+	iterCount := 1
+	for {
+		select {
+		case <-c:
+			done = true
+		case t := <-ticker.C:
+			if err := sample(t); err != nil {
+				return err
+			}
+		}
+
+		if done {
+			break
+		}
+		if maxRuns > 0 && iterCount >= maxRuns {
+			break
+		}
+		iterCount++
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package knit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/isolation"
+	"github.com/openshift-kni/debug-tools/pkg/workqueue"
+)
+
+type workqueuesOptions struct {
+	plan         bool
+	housekeeping string
+}
+
+func NewWorkqueuesCommand(knitOpts *KnitOptions) *cobra.Command {
+	opts := &workqueuesOptions{}
+	wqs := &cobra.Command{
+		Use:   "workqueues",
+		Short: "show the unbound workqueues which can run on the isolated cpus",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showWorkqueues(cmd, knitOpts, opts, args)
+		},
+		Args: cobra.NoArgs,
+	}
+	wqs.Flags().BoolVar(&opts.plan, "plan", false, "show the cpumask writes needed to confine the unbound workqueues to the housekeeping cpus.")
+	wqs.Flags().StringVar(&opts.housekeeping, "housekeeping", "", "housekeeping cpu set for the plan (see man (7) cpuset - List format). Default is the online cpus which are not isolated.")
+	return wqs
+}
+
+type workqueueEntry struct {
+	Name string `json:"name"`
+	Nice int    `json:"nice"`
+	CPUs []int  `json:"cpus"`
+}
+
+type workqueuesReport struct {
+	Isolated    []int `json:"isolated"`
+	UnboundCPUs []int `json:"unboundCPUs"`
+	// Unbound are the unbound workqueues which can run on the isolated cpus
+	Unbound []workqueueEntry `json:"unbound"`
+	// PerCPU are the per-cpu workqueues, which run on the cpus the work is queued on
+	PerCPU []string `json:"perCPU"`
+}
+
+type workqueuesPlan struct {
+	Writes []workqueue.Write `json:"writes"`
+}
+
+func showWorkqueues(cmd *cobra.Command, knitOpts *KnitOptions, opts *workqueuesOptions, args []string) error {
+	info, err := isolation.New(knitOpts.Log, knitOpts.ProcFSRoot, knitOpts.SysFSRoot).ReadInfo()
+	if err != nil {
+		return err
+	}
+	isolated, housekeeping := IsolatedCPUs(knitOpts, info)
+
+	wh := workqueue.New(knitOpts.Log, knitOpts.SysFSRoot)
+	wqInfo, err := wh.ReadInfo()
+	if err != nil {
+		return fmt.Errorf("error reading the workqueues from %q: %v", knitOpts.SysFSRoot, err)
+	}
+
+	if opts.plan {
+		if opts.housekeeping != "" {
+			housekeeping, err = cpuset.Parse(opts.housekeeping)
+			if err != nil {
+				return fmt.Errorf("error parsing %q: %v", opts.housekeeping, err)
+			}
+		}
+		if housekeeping.Size() == 0 {
+			return fmt.Errorf("empty housekeeping cpu set")
+		}
+		plan := workqueuesPlan{
+			Writes: wh.Plan(wqInfo, housekeeping),
+		}
+		if knitOpts.JsonOutput {
+			json.NewEncoder(os.Stdout).Encode(plan)
+			return nil
+		}
+		for _, wr := range plan.Writes {
+			fmt.Println(wr.String())
+		}
+		return nil
+	}
+
+	report := workqueuesReport{
+		Isolated:    isolated.List(),
+		UnboundCPUs: wqInfo.UnboundCPUs.List(),
+	}
+	for _, wq := range wqInfo.Overlapping(isolated) {
+		report.Unbound = append(report.Unbound, workqueueEntry{
+			Name: wq.Name,
+			Nice: wq.Nice,
+			CPUs: wqInfo.EffectiveCPUs(wq).List(),
+		})
+	}
+	for _, wq := range wqInfo.Workqueues {
+		if wq.PerCPU {
+			report.PerCPU = append(report.PerCPU, wq.Name)
+		}
+	}
+
+	if knitOpts.JsonOutput {
+		json.NewEncoder(os.Stdout).Encode(report)
+		return nil
+	}
+
+	fmt.Printf("isolated cpus %v unbound workqueues cpumask %v\n", isolated, wqInfo.UnboundCPUs)
+	if overlap := wqInfo.UnboundCPUs.Intersection(isolated); overlap.Size() > 0 {
+		fmt.Printf("the unbound workqueues cpumask includes the isolated cpus %v\n", overlap)
+	}
+	if len(report.Unbound) > 0 {
+		fmt.Printf("\nunbound workqueues which can run on isolated cpus:\n")
+		for _, wq := range report.Unbound {
+			fmt.Printf("%-32s nice %3d can run on %v\n", wq.Name, wq.Nice, cpuset.New(wq.CPUs...))
+		}
+	}
+	if len(report.PerCPU) > 0 {
+		fmt.Printf("\nper-cpu workqueues, running on the cpus the work is queued on: %d\n", len(report.PerCPU))
+	}
+	return nil
+}
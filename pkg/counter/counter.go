@@ -0,0 +1,28 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+// Package counter handles the monotonic counters the kernel exposes, like the ones in /proc/stat.
+package counter
+
+// Delta returns how much the counter grew from `prev` to `last`. The kernel counters are
+// monotonic, so a smaller `last` means the counter was reset, e.g. the thread is a new one
+// reusing the same id, and we report no growth rather than wrapping around.
+func Delta(prev, last uint64) uint64 {
+	if last < prev {
+		return 0
+	}
+	return last - prev
+}
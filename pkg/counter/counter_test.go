@@ -0,0 +1,43 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package counter_test
+
+import (
+	"testing"
+
+	"github.com/openshift-kni/debug-tools/pkg/counter"
+)
+
+func TestDelta(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prev     uint64
+		last     uint64
+		expected uint64
+	}{
+		{"unchanged", 42, 42, 0},
+		{"grown", 42, 50, 8},
+		{"reset", 42, 3, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := counter.Delta(tc.prev, tc.last); got != tc.expected {
+				t.Errorf("got=%d expected=%d", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+// Package cpumask converts the hexadecimal cpu masks the kernel and the system tools use
+// (e.g. /proc/irq/N/smp_affinity, rps_cpus, IRQBALANCE_BANNED_CPUS) from and to cpu sets.
+package cpumask
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	cpuset "k8s.io/utils/cpuset"
+)
+
+const wordBits = 32
+
+// Parse parses masks like "ff,ffffffff", which are comma-separated 32 bit words, most significant first.
+// A "0x" prefix is tolerated, because some configuration files use it.
+func Parse(mask string) (cpuset.CPUSet, error) {
+	mask = strings.TrimSpace(mask)
+	mask = strings.TrimPrefix(strings.TrimPrefix(mask, "0x"), "0X")
+	if mask == "" {
+		return cpuset.New(), nil
+	}
+
+	words := strings.Split(mask, ",")
+	if len(words) == 1 {
+		// single word masks, like the ones in the configuration files, can be arbitrarily long
+		words = splitWords(mask)
+	}
+
+	var cpus []int
+	for idx := range words {
+		// least significant word first
+		word := words[len(words)-1-idx]
+		if word == "" || len(word) > wordBits/4 {
+			return cpuset.New(), fmt.Errorf("malformed cpu mask %q: bad word %q", mask, word)
+		}
+		val, err := strconv.ParseUint(word, 16, wordBits)
+		if err != nil {
+			return cpuset.New(), fmt.Errorf("malformed cpu mask %q: %v", mask, err)
+		}
+		for bit := 0; bit < wordBits; bit++ {
+			if val&(1<<bit) != 0 {
+				cpus = append(cpus, idx*wordBits+bit)
+			}
+		}
+	}
+	return cpuset.New(cpus...), nil
+}
+
+func splitWords(mask string) []string {
+	var words []string
+	for len(mask) > wordBits/4 {
+		words = append([]string{mask[len(mask)-wordBits/4:]}, words...)
+		mask = mask[:len(mask)-wordBits/4]
+	}
+	return append([]string{mask}, words...)
+}
+
+// Format returns the mask of the cpus in the same format the kernel uses, like "ff,ffffffff".
+func Format(cpus cpuset.CPUSet) string {
+	cpuList := cpus.List()
+	if len(cpuList) == 0 {
+		return "0"
+	}
+	words := make([]uint32, cpuList[len(cpuList)-1]/wordBits+1)
+	for _, cpu := range cpuList {
+		words[cpu/wordBits] |= 1 << (cpu % wordBits)
+	}
+	items := make([]string, 0, len(words))
+	for idx := len(words) - 1; idx >= 0; idx-- {
+		items = append(items, fmt.Sprintf("%08x", words[idx]))
+	}
+	return strings.Join(items, ",")
+}
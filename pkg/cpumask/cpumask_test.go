@@ -0,0 +1,84 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package cpumask_test
+
+import (
+	"testing"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/cpumask"
+)
+
+func TestParse(t *testing.T) {
+	var testCases = []struct {
+		mask     string
+		expected string
+		valid    bool
+	}{
+		{"0", "", true},
+		{"", "", true},
+		{"f", "0-3", true},
+		{"0000000f\n", "0-3", true},
+		{"0x0c", "2-3", true},
+		{"00000001,00000000", "32", true},
+		{"ff,ffffffff", "0-39", true},
+		{"100000003", "0-1,32", true},
+		{"00000000,00000000,80000001", "0,31", true},
+		{"zz", "", false},
+		{"1,,0", "", false},
+		{"1,123456789", "", false},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.mask, func(t *testing.T) {
+			cpus, err := cpumask.Parse(tt.mask)
+			if (err == nil) != tt.valid {
+				t.Fatalf("unexpected error status: %v", err)
+			}
+			if !tt.valid {
+				return
+			}
+			if cpus.String() != tt.expected {
+				t.Errorf("got %q expected %q", cpus.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	var testCases = []struct {
+		cpus     cpuset.CPUSet
+		expected string
+	}{
+		{cpuset.New(), "0"},
+		{cpuset.New(0, 1, 2, 3), "0000000f"},
+		{cpuset.New(0, 32), "00000001,00000001"},
+		{cpuset.New(2, 3, 40), "00000100,0000000c"},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.expected, func(t *testing.T) {
+			mask := cpumask.Format(tt.cpus)
+			if mask != tt.expected {
+				t.Errorf("got %q expected %q", mask, tt.expected)
+			}
+			cpus, err := cpumask.Parse(mask)
+			if err != nil || !cpus.Equals(tt.cpus) {
+				t.Errorf("roundtrip failed: %v %v", cpus, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package cpustat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-kni/debug-tools/pkg/counter"
+	"github.com/openshift-kni/debug-tools/pkg/fswrap"
+)
+
+// Times are the time a cpu spent in each state, in USER_HZ ticks. See man 5 proc, /proc/stat.
+type Times struct {
+	User    uint64 `json:"user"`
+	Nice    uint64 `json:"nice"`
+	System  uint64 `json:"system"`
+	Idle    uint64 `json:"idle"`
+	IOWait  uint64 `json:"iowait"`
+	IRQ     uint64 `json:"irq"`
+	SoftIRQ uint64 `json:"softirq"`
+	Steal   uint64 `json:"steal"`
+	// Guest and GuestNice are already accounted in User and Nice
+	Guest     uint64 `json:"guest"`
+	GuestNice uint64 `json:"guestNice"`
+}
+
+// Total returns all the time accounted, without counting twice the guest time.
+func (ti Times) Total() uint64 {
+	return ti.User + ti.Nice + ti.System + ti.Idle + ti.IOWait + ti.IRQ + ti.SoftIRQ + ti.Steal
+}
+
+// Delta returns the time spent from `ti` to `last`. Counters going backwards (e.g. cpu hotplug) count as zero.
+func (ti Times) Delta(last Times) Times {
+	return Times{
+		User:      counter.Delta(ti.User, last.User),
+		Nice:      counter.Delta(ti.Nice, last.Nice),
+		System:    counter.Delta(ti.System, last.System),
+		Idle:      counter.Delta(ti.Idle, last.Idle),
+		IOWait:    counter.Delta(ti.IOWait, last.IOWait),
+		IRQ:       counter.Delta(ti.IRQ, last.IRQ),
+		SoftIRQ:   counter.Delta(ti.SoftIRQ, last.SoftIRQ),
+		Steal:     counter.Delta(ti.Steal, last.Steal),
+		Guest:     counter.Delta(ti.Guest, last.Guest),
+		GuestNice: counter.Delta(ti.GuestNice, last.GuestNice),
+	}
+}
+
+// Usage is the percentage of the time spent in each state
+type Usage struct {
+	User    float64 `json:"user"`
+	Nice    float64 `json:"nice"`
+	System  float64 `json:"system"`
+	Idle    float64 `json:"idle"`
+	IOWait  float64 `json:"iowait"`
+	IRQ     float64 `json:"irq"`
+	SoftIRQ float64 `json:"softirq"`
+	Steal   float64 `json:"steal"`
+	Guest   float64 `json:"guest"`
+	// Noise is the time stolen from the workload: system, irq, softirq and steal
+	Noise float64 `json:"noise"`
+}
+
+func (ti Times) Usage() Usage {
+	total := ti.Total()
+	if total == 0 {
+		return Usage{}
+	}
+	pct := func(val uint64) float64 {
+		return 100.0 * float64(val) / float64(total)
+	}
+	return Usage{
+		User:    pct(ti.User),
+		Nice:    pct(ti.Nice),
+		System:  pct(ti.System),
+		Idle:    pct(ti.Idle),
+		IOWait:  pct(ti.IOWait),
+		IRQ:     pct(ti.IRQ),
+		SoftIRQ: pct(ti.SoftIRQ),
+		Steal:   pct(ti.Steal),
+		Guest:   pct(ti.Guest + ti.GuestNice),
+		Noise:   pct(ti.System + ti.IRQ + ti.SoftIRQ + ti.Steal),
+	}
+}
+
+// CPUid -> times
+type Stats map[int]Times
+
+func (S Stats) Delta(X Stats) Stats {
+	ret := make(Stats)
+	for cpuid, times := range X {
+		ret[cpuid] = S[cpuid].Delta(times)
+	}
+	return ret
+}
+
+// maxStatLineLen is the longest line of /proc/stat we can parse
+const maxStatLineLen = 4 * 1024 * 1024
+
+type Handler struct {
+	log        *log.Logger
+	procfsRoot string
+	fs         fswrap.FSWrapper
+}
+
+func New(logger *log.Logger, procfsRoot string) *Handler {
+	return &Handler{
+		log:        logger,
+		procfsRoot: procfsRoot,
+		fs:         fswrap.FSWrapper{Log: logger},
+	}
+}
+
+func (handler *Handler) ReadStats() (Stats, error) {
+	src, err := handler.fs.Open(filepath.Join(handler.procfsRoot, "stat"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading stat from %q: %v", handler.procfsRoot, err)
+	}
+	defer src.Close()
+	return parseStat(handler.log, src)
+}
+
+// parseStat parses the per-cpu lines of /proc/stat, like
+// "cpu0 113408 0 34781 256126 601 0 6 4285 0 0". Older kernels report less columns.
+func parseStat(logger *log.Logger, rd io.Reader) (Stats, error) {
+	stats := make(Stats)
+	scanner := bufio.NewScanner(rd)
+	// the "intr" line which follows the cpu lines has a counter per IRQ, and can exceed the default buffer size
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxStatLineLen)
+	for scanner.Scan() {
+		items := strings.Fields(scanner.Text())
+		if len(items) < 2 || !strings.HasPrefix(items[0], "cpu") {
+			if len(stats) > 0 {
+				// the per-cpu lines are all reported first, we don't need the rest
+				break
+			}
+			continue
+		}
+		if items[0] == "cpu" {
+			// we want only the per-cpu lines, not the aggregate
+			continue
+		}
+		cpuid, err := strconv.Atoi(strings.TrimPrefix(items[0], "cpu"))
+		if err != nil {
+			logger.Printf("Error parsing cpuid %q: %v", items[0], err)
+			continue
+		}
+		var vals [10]uint64
+		for idx, item := range items[1:] {
+			if idx >= len(vals) {
+				break
+			}
+			vals[idx], err = strconv.ParseUint(item, 10, 64)
+			if err != nil {
+				return stats, fmt.Errorf("error parsing %q for cpu %d: %v", item, cpuid, err)
+			}
+		}
+		stats[cpuid] = Times{
+			User:      vals[0],
+			Nice:      vals[1],
+			System:    vals[2],
+			Idle:      vals[3],
+			IOWait:    vals[4],
+			IRQ:       vals[5],
+			SoftIRQ:   vals[6],
+			Steal:     vals[7],
+			Guest:     vals[8],
+			GuestNice: vals[9],
+		}
+	}
+	return stats, scanner.Err()
+}
@@ -0,0 +1,160 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package cpustat_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/cpustat"
+)
+
+var nullLog = log.New(ioutil.Discard, "", 0)
+
+// cpu1 is offline
+const fakeStatInit = `cpu  1017426 12 68513 1929770 700 0 1432 30 0 0
+cpu0 10000 10 30000 900000 500 0 1000 0 0 0
+cpu2 500000 1 200 10000 0 0 10 0 0 0
+cpu3 500000 1 200 10000 100 0 10 30 0 0
+intr 1854819 0 0 0
+ctxt 42
+btime 1760000000
+`
+
+const fakeStatLast = `cpu  1018426 12 68521 1930770 700 3 1434 40 0 0
+cpu0 10000 10 30000 901000 500 0 1000 0 0 0
+cpu2 500500 1 200 10000 0 0 10 0 0 0
+cpu3 500477 1 208 10000 100 3 12 40 0 0
+intr 1854900 0 0 0
+ctxt 84
+btime 1760000000
+`
+
+func TestReadStats(t *testing.T) {
+	procDir := makeFakeProc(t, fakeStatInit)
+	defer os.RemoveAll(filepath.Dir(procDir)) // clean up
+
+	stats, err := cpustat.New(nullLog, procDir).ReadStats()
+	if err != nil {
+		t.Fatalf("ReadStats failed: %v", err)
+	}
+	if len(stats) != 3 {
+		t.Fatalf("unexpected stats: %v", stats)
+	}
+	if _, ok := stats[1]; ok {
+		t.Errorf("unexpected stats for offline cpu 1")
+	}
+	expected := cpustat.Times{User: 500000, Nice: 1, System: 200, Idle: 10000, IOWait: 100, SoftIRQ: 10, Steal: 30}
+	if stats[3] != expected {
+		t.Errorf("cpu 3 mismatch: got %+v expected %+v", stats[3], expected)
+	}
+}
+
+func TestReadStatsLongIntrLine(t *testing.T) {
+	// enough IRQs to exceed the default bufio.Scanner buffer
+	intr := "intr 1854819" + strings.Repeat(" 123456", 20000)
+	procDir := makeFakeProc(t, strings.Replace(fakeStatInit, "intr 1854819 0 0 0", intr, 1))
+	defer os.RemoveAll(filepath.Dir(procDir)) // clean up
+
+	stats, err := cpustat.New(nullLog, procDir).ReadStats()
+	if err != nil {
+		t.Fatalf("ReadStats failed: %v", err)
+	}
+	if len(stats) != 3 {
+		t.Fatalf("unexpected stats: %v", stats)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	procDir := makeFakeProc(t, fakeStatInit)
+	defer os.RemoveAll(filepath.Dir(procDir)) // clean up
+
+	ch := cpustat.New(nullLog, procDir)
+	initStats, err := ch.ReadStats()
+	if err != nil {
+		t.Fatalf("ReadStats failed: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(procDir, "stat"), []byte(fakeStatLast), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	lastStats, err := ch.ReadStats()
+	if err != nil {
+		t.Fatalf("ReadStats failed: %v", err)
+	}
+
+	delta := initStats.Delta(lastStats)
+	if us := delta[2].Usage(); us.User != 100 || us.Noise != 0 {
+		t.Errorf("cpu 2 should be a clean busy poller: %+v", us)
+	}
+	us := delta[3].Usage()
+	expected := cpustat.Usage{User: 95.4, System: 1.6, IRQ: 0.6, SoftIRQ: 0.4, Steal: 2, Noise: 4.6}
+	if !closeEnough(us, expected) {
+		t.Errorf("cpu 3 unexpected usage: got %+v expected %+v", us, expected)
+	}
+	if us := delta[0].Usage(); us.Idle != 100 {
+		t.Errorf("cpu 0 should be idle: %+v", us)
+	}
+
+	var buf bytes.Buffer
+	reporter := cpustat.NewReporter(&buf, false, 2, cpuset.New(2, 3))
+	reporter.Delta(time.Now(), initStats, lastStats)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+	if !strings.Contains(lines[1], "CPU=3 user= 95.4%") || !strings.HasSuffix(lines[1], "noise=  4.6%") {
+		t.Errorf("unexpected line: %q", lines[1])
+	}
+}
+
+func closeEnough(a, b cpustat.Usage) bool {
+	pairs := [][2]float64{
+		{a.User, b.User}, {a.Nice, b.Nice}, {a.System, b.System}, {a.Idle, b.Idle}, {a.IOWait, b.IOWait},
+		{a.IRQ, b.IRQ}, {a.SoftIRQ, b.SoftIRQ}, {a.Steal, b.Steal}, {a.Guest, b.Guest}, {a.Noise, b.Noise},
+	}
+	for _, pair := range pairs {
+		if math.Abs(pair[0]-pair[1]) > 0.001 {
+			return false
+		}
+	}
+	return true
+}
+
+func makeFakeProc(t *testing.T, stat string) string {
+	t.Helper()
+	rootDir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	procDir := filepath.Join(rootDir, "proc")
+	if err := os.Mkdir(procDir, 0755); err != nil {
+		t.Fatalf("Mkdir(%s) failed: %v", procDir, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(procDir, "stat"), []byte(stat), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	return procDir
+}
@@ -0,0 +1,146 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package cpustat
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+)
+
+type Reporter interface {
+	Delta(ts time.Time, prevStats, lastStats Stats)
+	Summary(initTs time.Time, initStats, lastStats Stats)
+}
+
+func NewReporter(sink io.Writer, jsonOutput bool, verbose int, cpus cpuset.CPUSet) Reporter {
+	if jsonOutput {
+		return &reporterJSON{
+			verbose: verbose,
+			cpus:    cpus,
+			sink:    sink,
+		}
+	}
+	return &reporterText{
+		verbose: verbose,
+		cpus:    cpus,
+		sink:    sink,
+	}
+}
+
+type reporterText struct {
+	verbose int
+	cpus    cpuset.CPUSet
+	sink    io.Writer
+}
+
+func (rt *reporterText) Delta(ts time.Time, prevStats, lastStats Stats) {
+	if rt.verbose < 2 {
+		return
+	}
+	delta := prevStats.Delta(lastStats)
+	for _, cpuid := range rt.cpus.List() {
+		times, ok := delta[cpuid]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(rt.sink, "%v CPU=%d %s\n", ts, cpuid, formatUsage(times.Usage()))
+	}
+}
+
+func (rt *reporterText) Summary(initTs time.Time, initStats, lastStats Stats) {
+	if rt.verbose < 1 {
+		return
+	}
+	timeDelta := time.Now().Sub(initTs)
+	delta := initStats.Delta(lastStats)
+
+	fmt.Fprintf(rt.sink, "\nCPU time summary on cpus %v after %v\n", rt.cpus, timeDelta)
+	for _, cpuid := range rt.cpus.List() {
+		times, ok := delta[cpuid]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(rt.sink, "CPU=%d %s\n", cpuid, formatUsage(times.Usage()))
+	}
+}
+
+func formatUsage(us Usage) string {
+	return fmt.Sprintf("user=%5.1f%% nice=%5.1f%% system=%5.1f%% idle=%5.1f%% iowait=%5.1f%% irq=%5.1f%% softirq=%5.1f%% steal=%5.1f%% guest=%5.1f%% noise=%5.1f%%",
+		us.User, us.Nice, us.System, us.Idle, us.IOWait, us.IRQ, us.SoftIRQ, us.Steal, us.Guest, us.Noise)
+}
+
+type reporterJSON struct {
+	verbose int
+	cpus    cpuset.CPUSet
+	sink    io.Writer
+}
+
+type cpustatDelta struct {
+	Timestamp time.Time     `json:"timestamp"`
+	Usage     map[int]Usage `json:"usage"`
+}
+
+func (rj *reporterJSON) Delta(ts time.Time, prevStats, lastStats Stats) {
+	if rj.verbose < 2 {
+		return
+	}
+	res := cpustatDelta{
+		Timestamp: ts,
+		Usage:     usageForCPUs(rj.cpus, prevStats.Delta(lastStats)),
+	}
+	json.NewEncoder(rj.sink).Encode(res)
+}
+
+type cpustatSummary struct {
+	// Elapsed is a duration string, like "1m30s"
+	Elapsed string        `json:"elapsed"`
+	Usage   map[int]Usage `json:"usage"`
+	// Times are the raw times spent in each state, in USER_HZ ticks
+	Times Stats `json:"times"`
+}
+
+func (rj *reporterJSON) Summary(initTs time.Time, initStats, lastStats Stats) {
+	if rj.verbose < 1 {
+		return
+	}
+	delta := initStats.Delta(lastStats)
+	res := cpustatSummary{
+		Elapsed: time.Now().Sub(initTs).String(),
+		Usage:   usageForCPUs(rj.cpus, delta),
+		Times:   make(Stats),
+	}
+	for cpuid := range res.Usage {
+		res.Times[cpuid] = delta[cpuid]
+	}
+	json.NewEncoder(rj.sink).Encode(res)
+}
+
+func usageForCPUs(cpus cpuset.CPUSet, stats Stats) map[int]Usage {
+	res := make(map[int]Usage)
+	for _, cpuid := range cpus.List() {
+		times, ok := stats[cpuid]
+		if !ok {
+			continue
+		}
+		res[cpuid] = times.Usage()
+	}
+	return res
+}
@@ -0,0 +1,105 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+// Package ethtool complements github.com/safchain/ethtool with the SIOCETHTOOL queries it lacks,
+// and with the helpers to make sense of the NIC statistics.
+package ethtool
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// see include/uapi/linux/ethtool.h
+const (
+	ethtoolGRingParam  = 0x00000010
+	ethtoolGPauseParam = 0x00000012
+)
+
+// Rings are the ring sizes, like `ethtool -g` shows. See struct ethtool_ringparam.
+type Rings struct {
+	Cmd               uint32 `json:"-"`
+	RxMaxPending      uint32 `json:"rxMaxPending"`
+	RxMiniMaxPending  uint32 `json:"rxMiniMaxPending"`
+	RxJumboMaxPending uint32 `json:"rxJumboMaxPending"`
+	TxMaxPending      uint32 `json:"txMaxPending"`
+	RxPending         uint32 `json:"rxPending"`
+	RxMiniPending     uint32 `json:"rxMiniPending"`
+	RxJumboPending    uint32 `json:"rxJumboPending"`
+	TxPending         uint32 `json:"txPending"`
+}
+
+// Pause are the pause frame settings, like `ethtool -a` shows. See struct ethtool_pauseparam.
+type Pause struct {
+	Cmd     uint32 `json:"-"`
+	Autoneg uint32 `json:"autoneg"`
+	RxPause uint32 `json:"rxPause"`
+	TxPause uint32 `json:"txPause"`
+}
+
+// ifreq matches struct ifreq, whose union is 24 bytes long on 64 bit architectures
+type ifreq struct {
+	name [unix.IFNAMSIZ]byte
+	data uintptr
+	_    [24 - unsafe.Sizeof(uintptr(0))]byte
+}
+
+type Handle struct {
+	fd int
+}
+
+func New() (*Handle, error) {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM, unix.IPPROTO_IP)
+	if err != nil {
+		return nil, err
+	}
+	return &Handle{
+		fd: fd,
+	}, nil
+}
+
+func (hnd *Handle) Close() {
+	unix.Close(hnd.fd)
+}
+
+func (hnd *Handle) GetRings(iface string) (Rings, error) {
+	rings := Rings{
+		Cmd: ethtoolGRingParam,
+	}
+	err := hnd.ioctl(iface, uintptr(unsafe.Pointer(&rings)))
+	return rings, err
+}
+
+func (hnd *Handle) GetPause(iface string) (Pause, error) {
+	pause := Pause{
+		Cmd: ethtoolGPauseParam,
+	}
+	err := hnd.ioctl(iface, uintptr(unsafe.Pointer(&pause)))
+	return pause, err
+}
+
+func (hnd *Handle) ioctl(iface string, data uintptr) error {
+	ifr := ifreq{
+		data: data,
+	}
+	copy(ifr.name[:unix.IFNAMSIZ-1], iface)
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(hnd.fd), unix.SIOCETHTOOL, uintptr(unsafe.Pointer(&ifr)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package ethtool
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NoQueue marks the device-wide statistics
+const NoQueue = -1
+
+// Stats are the NIC statistics, like `ethtool -S` shows
+type Stats map[string]uint64
+
+// the drivers don't agree on how to name the per-queue statistics
+var queueStatPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?:rx|tx)_queue_(\d+)_`), // ixgbe, igb, virtio_net: rx_queue_0_packets
+	regexp.MustCompile(`^(?:rx|tx)-(\d+)\.`),      // i40e, ice: rx-0.packets
+	regexp.MustCompile(`^(?:rx|tx)(\d+)_`),        // mlx5: rx0_packets
+	regexp.MustCompile(`^queue_(\d+)_(?:rx|tx)_`), // ena: queue_0_rx_cnt
+	regexp.MustCompile(`^\[(\d+)\]: `),            // bnxt: [0]: rx_ucast_packets
+}
+
+// QueueOf returns the queue the statistic `name` belongs to, or NoQueue for the device-wide statistics.
+func QueueOf(name string) int {
+	for _, re := range queueStatPatterns {
+		match := re.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		queue, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		return queue
+	}
+	return NoQueue
+}
+
+var problemStatMarkers = []string{"drop", "miss", "err", "discard", "fifo", "overrun", "no_buf", "nobuf", "out_of_buffer"}
+
+// IsProblem tells if the statistic `name` counts packets lost or not processed in time,
+// which are the ones worth watching when chasing latency or packet loss.
+func IsProblem(name string) bool {
+	lname := strings.ToLower(name)
+	for _, marker := range problemStatMarkers {
+		if strings.Contains(lname, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+type StatDelta struct {
+	Name  string  `json:"name"`
+	Queue int     `json:"queue"`
+	Delta uint64  `json:"delta"`
+	Rate  float64 `json:"rate"`
+}
+
+// Delta returns the statistics which changed from `prev` to `last`, `elapsed` time apart,
+// the device-wide ones first, then by queue and by name. Counters going backwards (e.g. device reset)
+// and statistics missing in either sample are skipped.
+func Delta(prev, last Stats, elapsed time.Duration) []StatDelta {
+	var res []StatDelta
+	for name, lastVal := range last {
+		prevVal, ok := prev[name]
+		if !ok || lastVal <= prevVal {
+			continue
+		}
+		sd := StatDelta{
+			Name:  name,
+			Queue: QueueOf(name),
+			Delta: lastVal - prevVal,
+		}
+		if elapsed > 0 {
+			sd.Rate = float64(sd.Delta) / elapsed.Seconds()
+		}
+		res = append(res, sd)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Queue != res[j].Queue {
+			return res[i].Queue < res[j].Queue
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
@@ -0,0 +1,106 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package ethtool_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/debug-tools/pkg/ethtool"
+)
+
+func TestQueueOf(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		expected int
+	}{
+		{"rx_packets", ethtool.NoQueue},
+		{"rx_missed_errors", ethtool.NoQueue},
+		{"rx_queue_3_drops", 3},
+		{"tx_queue_12_bytes", 12},
+		{"rx-7.packets", 7},
+		{"tx-0.bytes", 0},
+		{"rx5_packets", 5},
+		{"tx15_xdp_tx_drops", 15},
+		{"queue_2_rx_cnt", 2},
+		{"[4]: rx_discards", 4},
+		{"rx_xdp_drops", ethtool.NoQueue},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ethtool.QueueOf(tt.name); got != tt.expected {
+				t.Errorf("got %d expected %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsProblem(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		expected bool
+	}{
+		{"rx_packets", false},
+		{"rx_missed_errors", true},
+		{"rx_queue_3_drops", true},
+		{"rx_fifo_errors", true},
+		{"[4]: rx_discards", true},
+		{"rx_out_of_buffer", true},
+		{"rx0_buff_alloc_err", true},
+		{"tx_kicks", false},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ethtool.IsProblem(tt.name); got != tt.expected {
+				t.Errorf("got %v expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDelta(t *testing.T) {
+	prev := ethtool.Stats{
+		"rx_packets":       1000,
+		"rx_missed_errors": 4,
+		"rx1_packets":      500,
+		"rx0_packets":      500,
+		"rx0_drops":        10,
+		"tx_packets":       300,
+	}
+	last := ethtool.Stats{
+		"rx_packets":       1400,
+		"rx_missed_errors": 6,
+		"rx1_packets":      700,
+		"rx0_packets":      700,
+		"rx0_drops":        10,
+		// the device was reset
+		"tx_packets": 10,
+		"rx1_drops":  3,
+	}
+
+	got := ethtool.Delta(prev, last, 2*time.Second)
+	expected := []ethtool.StatDelta{
+		{Name: "rx_missed_errors", Queue: ethtool.NoQueue, Delta: 2, Rate: 1},
+		{Name: "rx_packets", Queue: ethtool.NoQueue, Delta: 400, Rate: 200},
+		{Name: "rx0_packets", Queue: 0, Delta: 200, Rate: 100},
+		{Name: "rx1_packets", Queue: 1, Delta: 200, Rate: 100},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected delta\ngot=%+v\nexpected=%+v", got, expected)
+	}
+}
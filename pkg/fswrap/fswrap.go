@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 )
 
 type FSWrapper struct {
@@ -40,3 +41,13 @@ func (fs FSWrapper) ReadDir(dirname string) ([]os.FileInfo, error) {
 	fs.Log.Printf("fswrap %-8s %q", "ReadDir", dirname)
 	return ioutil.ReadDir(dirname)
 }
+
+func (fs FSWrapper) WriteFile(filename string, data []byte, perm os.FileMode) error {
+	fs.Log.Printf("fswrap %-8s %q", "WriteFile", filename)
+	return ioutil.WriteFile(filename, data, perm)
+}
+
+func (fs FSWrapper) EvalSymlinks(path string) (string, error) {
+	fs.Log.Printf("fswrap %-8s %q", "EvalSymlinks", path)
+	return filepath.EvalSymlinks(path)
+}
@@ -0,0 +1,133 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqbalance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
+	"github.com/openshift-kni/debug-tools/pkg/isolation"
+)
+
+// maxListedIRQs limits the IRQs listed in the messages, to keep them readable
+const maxListedIRQs = 16
+
+// Check compares the irqbalance settings with the `isolated` cpus and with the current affinities
+// of the IRQs, and reports every drift found.
+func Check(info Info, isolated cpuset.CPUSet, irqInfos []irqs.Info) []isolation.Finding {
+	var ck isolation.Findings
+	none := cpuset.New()
+
+	if info.Config == nil {
+		ck.Add(isolation.SeverityInfo, "irqbalance-config-missing", none, "no irqbalance configuration found in %s", strings.Join(ConfigPaths, ", "))
+	}
+	if !info.Running() {
+		ck.Add(isolation.SeverityInfo, "irqbalance-not-running", none, "irqbalance is not running, the IRQ affinities are static")
+	}
+
+	st := info.Effective()
+	banned := st.Banned()
+	if info.Running() {
+		if banned.Size() == 0 && isolated.Size() > 0 {
+			ck.Add(isolation.SeverityError, "banned-cpus-unset", isolated,
+				"irqbalance is running without banned cpus, it can move IRQs on the isolated cpus")
+		} else if notBanned := isolated.Difference(banned); notBanned.Size() > 0 {
+			ck.Add(isolation.SeverityError, "banned-cpus-incomplete", notBanned,
+				"isolated cpus not banned by irqbalance (banned: %s)", banned.String())
+		}
+		if info.Runtime == nil {
+			ck.Add(isolation.SeverityInfo, "irqbalance-runtime-unknown", none,
+				"cannot read the environment of irqbalance (pid %d), using the configuration file", info.PIDs[0])
+		} else if info.Config != nil && !info.Config.Banned().Equals(info.Runtime.Banned()) {
+			ck.Add(isolation.SeverityWarning, "banned-cpus-not-applied", symmetricDifference(info.Config.Banned(), info.Runtime.Banned()),
+				"/%s bans cpus %s but irqbalance (pid %d) bans %s, restart it to apply", info.ConfigPath, info.Config.Banned().String(), info.PIDs[0], info.Runtime.Banned().String())
+		}
+		if st.OneShot {
+			ck.Add(isolation.SeverityInfo, "irqbalance-oneshot", none, "irqbalance runs in oneshot mode, it balances the IRQs only once")
+		}
+	}
+	if extra := banned.Difference(isolated); extra.Size() > 0 && isolated.Size() > 0 {
+		ck.Add(isolation.SeverityWarning, "banned-cpus-not-isolated", extra, "irqbalance bans cpus which are not isolated")
+	}
+	if st.BannedCPUs != nil && st.BannedCPUList != nil && !st.BannedCPUs.Equals(*st.BannedCPUList) {
+		ck.Add(isolation.SeverityWarning, "banned-cpus-conflict", symmetricDifference(*st.BannedCPUs, *st.BannedCPUList),
+			"%s=%s and %s=%s disagree, newer irqbalance versions use only the latter", VarBannedCPUs, st.BannedCPUs.String(), VarBannedCPUList, st.BannedCPUList.String())
+	}
+	if st.PolicyScript != "" {
+		if info.PolicyScriptFound {
+			ck.Add(isolation.SeverityInfo, "policy-script", none, "irqbalance uses the policy script %s, which can override the banned cpus", st.PolicyScript)
+		} else {
+			ck.Add(isolation.SeverityWarning, "policy-script-missing", none, "irqbalance is configured to use the policy script %s, which is not found", st.PolicyScript)
+		}
+	}
+
+	checkIRQs(&ck, info, isolated, irqInfos)
+	return ck
+}
+
+func checkIRQs(ck *isolation.Findings, info Info, isolated cpuset.CPUSet, irqInfos []irqs.Info) {
+	var irqList []string
+	var managedCount, unmovableCount int
+	cpus := cpuset.New()
+	for _, irqInfo := range irqInfos {
+		overlap := irqInfo.EffectiveCPUs.Intersection(isolated)
+		if overlap.Size() == 0 {
+			continue
+		}
+		// irqbalance can't do anything about them
+		if irqInfo.Managed {
+			managedCount++
+			continue
+		}
+		if irqInfo.Unmovable {
+			unmovableCount++
+			continue
+		}
+		cpus = cpus.Union(overlap)
+		irqList = append(irqList, strconv.Itoa(irqInfo.IRQ))
+	}
+	if len(irqList) > 0 {
+		sev := isolation.SeverityWarning
+		if info.Running() {
+			sev = isolation.SeverityError
+		}
+		ck.Add(sev, "irqs-on-isolated-cpus", cpus, "%d IRQs run on isolated cpus: %s", len(irqList), summarizeList(irqList))
+	}
+	if managedCount > 0 {
+		ck.Add(isolation.SeverityInfo, "managed-irqs-on-isolated-cpus", cpuset.New(),
+			"%d kernel-managed IRQs run on isolated cpus, irqbalance cannot move them", managedCount)
+	}
+	if unmovableCount > 0 {
+		ck.Add(isolation.SeverityInfo, "unmovable-irqs-on-isolated-cpus", cpuset.New(),
+			"%d IRQs whose affinity cannot be set run on isolated cpus, irqbalance cannot move them", unmovableCount)
+	}
+}
+
+func summarizeList(items []string) string {
+	if len(items) <= maxListedIRQs {
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(items[:maxListedIRQs], ","), len(items)-maxListedIRQs)
+}
+
+func symmetricDifference(a, b cpuset.CPUSet) cpuset.CPUSet {
+	return a.Difference(b).Union(b.Difference(a))
+}
@@ -0,0 +1,103 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqbalance_test
+
+import (
+	"reflect"
+	"testing"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/irqbalance"
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
+)
+
+func TestCheck(t *testing.T) {
+	configBanned := cpuset.New(2, 3, 4, 5)
+	runtimeBanned := cpuset.New(2, 3)
+	runtimeBannedList := cpuset.New(2)
+
+	info := irqbalance.Info{
+		PIDs:       []int{1342},
+		ConfigPath: "etc/sysconfig/irqbalance",
+		Config: &irqbalance.Settings{
+			BannedCPUs: &configBanned,
+		},
+		Runtime: &irqbalance.Settings{
+			BannedCPUs:    &runtimeBanned,
+			BannedCPUList: &runtimeBannedList,
+			Args:          "--policyscript=/etc/irqbalance.d/policy.sh",
+			PolicyScript:  "/etc/irqbalance.d/policy.sh",
+		},
+	}
+	irqInfos := []irqs.Info{
+		{IRQ: 0, AffinityCPUs: cpuset.New(0, 1), EffectiveCPUs: cpuset.New(0)},
+		{IRQ: 126, AffinityCPUs: cpuset.New(4, 5), EffectiveCPUs: cpuset.New(4), Managed: true, Unmovable: true},
+		{IRQ: 127, AffinityCPUs: cpuset.New(3), EffectiveCPUs: cpuset.New(3), Unmovable: true},
+		{IRQ: 153, AffinityCPUs: cpuset.New(4), EffectiveCPUs: cpuset.New(4)},
+		{IRQ: 154, AffinityCPUs: cpuset.New(0, 5), EffectiveCPUs: cpuset.New(5)},
+	}
+
+	findings := irqbalance.Check(info, cpuset.New(2, 3, 4, 5), irqInfos)
+	var codes []string
+	for _, fi := range findings {
+		codes = append(codes, fi.Code)
+	}
+	expected := []string{
+		"banned-cpus-incomplete",
+		"banned-cpus-not-applied",
+		"banned-cpus-conflict",
+		"policy-script-missing",
+		"irqs-on-isolated-cpus",
+		"managed-irqs-on-isolated-cpus",
+		"unmovable-irqs-on-isolated-cpus",
+	}
+	if !reflect.DeepEqual(codes, expected) {
+		t.Fatalf("unexpected findings\ngot=%v\nexpected=%v", codes, expected)
+	}
+	if cpus := findings[0].CPUs; !reflect.DeepEqual(cpus, []int{4, 5}) {
+		t.Errorf("unexpected not banned cpus: %v", cpus)
+	}
+	if cpus := findings[4].CPUs; !reflect.DeepEqual(cpus, []int{4, 5}) {
+		t.Errorf("unexpected cpus with IRQs: %v", cpus)
+	}
+	if msg := findings[4].Message; msg != "2 IRQs run on isolated cpus: 153,154" {
+		t.Errorf("unexpected message: %q", msg)
+	}
+}
+
+func TestCheckNotRunning(t *testing.T) {
+	irqInfos := []irqs.Info{
+		{IRQ: 153, AffinityCPUs: cpuset.New(4), EffectiveCPUs: cpuset.New(4)},
+	}
+	findings := irqbalance.Check(irqbalance.Info{}, cpuset.New(4), irqInfos)
+	var codes []string
+	for _, fi := range findings {
+		codes = append(codes, fi.Code)
+	}
+	expected := []string{
+		"irqbalance-config-missing",
+		"irqbalance-not-running",
+		"irqs-on-isolated-cpus",
+	}
+	if !reflect.DeepEqual(codes, expected) {
+		t.Fatalf("unexpected findings\ngot=%v\nexpected=%v", codes, expected)
+	}
+	if sev := findings[2].Severity; sev != "warning" {
+		t.Errorf("unexpected severity: %v", sev)
+	}
+}
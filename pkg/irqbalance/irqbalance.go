@@ -0,0 +1,279 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqbalance
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/cpumask"
+	"github.com/openshift-kni/debug-tools/pkg/fswrap"
+)
+
+const (
+	VarBannedCPUs    = "IRQBALANCE_BANNED_CPUS"
+	VarBannedCPUList = "IRQBALANCE_BANNED_CPULIST"
+	VarArgs          = "IRQBALANCE_ARGS"
+	VarOneShot       = "IRQBALANCE_ONESHOT"
+)
+
+// ConfigPaths are the irqbalance configuration files, relative to the root filesystem:
+// the first is used by the RHEL-like distributions, the second by the Debian-like distributions.
+var ConfigPaths = []string{
+	"etc/sysconfig/irqbalance",
+	"etc/default/irqbalance",
+}
+
+// Settings are the irqbalance variables, either from the configuration file or from the environment
+// of the running daemon. Values are nil if the variable is not set.
+type Settings struct {
+	// BannedCPUs is the value of IRQBALANCE_BANNED_CPUS, a cpu mask
+	BannedCPUs *cpuset.CPUSet
+	// BannedCPUList is the value of IRQBALANCE_BANNED_CPULIST, a cpu list supported by irqbalance >= 1.8
+	BannedCPUList *cpuset.CPUSet
+	Args          string
+	OneShot       bool
+	// PolicyScript is the --policyscript argument, if any
+	PolicyScript string
+}
+
+// Banned returns all the cpus irqbalance is told to leave alone.
+func (st Settings) Banned() cpuset.CPUSet {
+	res := cpuset.New()
+	if st.BannedCPUs != nil {
+		res = res.Union(*st.BannedCPUs)
+	}
+	if st.BannedCPUList != nil {
+		res = res.Union(*st.BannedCPUList)
+	}
+	return res
+}
+
+type Info struct {
+	// PIDs are the pids of the running irqbalance daemons, if any
+	PIDs []int
+	// ConfigPath is the configuration file found, relative to the root filesystem. Empty if none is found.
+	ConfigPath string
+	// Config are the settings in the configuration file, nil if no configuration file is found
+	Config *Settings
+	// Runtime are the settings in the environment of the running daemon, nil if not running or not readable.
+	// They differ from Config if the configuration changed after the daemon started.
+	Runtime *Settings
+	// PolicyScriptFound is true if the policy script in the effective settings exists in the root filesystem
+	PolicyScriptFound bool
+}
+
+func (info Info) Running() bool {
+	return len(info.PIDs) > 0
+}
+
+// Effective returns the settings the running daemon uses, falling back to the configuration file.
+func (info Info) Effective() Settings {
+	if info.Runtime != nil {
+		return *info.Runtime
+	}
+	if info.Config != nil {
+		return *info.Config
+	}
+	return Settings{}
+}
+
+// LikelyWriter tells if irqbalance can be the one which set the IRQ affinity to `cpus`.
+func (info Info) LikelyWriter(cpus cpuset.CPUSet) string {
+	if !info.Running() {
+		return "unknown, irqbalance is not running"
+	}
+	banned := info.Effective().Banned()
+	if banned.Intersection(cpus).Size() > 0 {
+		return fmt.Sprintf("unknown, irqbalance (pid %d) would not use its banned cpus %v", info.PIDs[0], banned)
+	}
+	return fmt.Sprintf("irqbalance (pid %d)", info.PIDs[0])
+}
+
+type Handler struct {
+	log        *log.Logger
+	procfsRoot string
+	rootfsRoot string
+	fs         fswrap.FSWrapper
+}
+
+func New(logger *log.Logger, procfsRoot, rootfsRoot string) *Handler {
+	return &Handler{
+		log:        logger,
+		procfsRoot: procfsRoot,
+		rootfsRoot: rootfsRoot,
+		fs:         fswrap.FSWrapper{Log: logger},
+	}
+}
+
+// ReadInfo finds the running irqbalance daemons and reads their settings.
+// Failures are not critical, because irqbalance is not necessarily installed.
+func (handler *Handler) ReadInfo() (Info, error) {
+	info := Info{}
+	pids, err := handler.findDaemons()
+	if err != nil {
+		return info, err
+	}
+	info.PIDs = pids
+
+	for _, configPath := range ConfigPaths {
+		src, err := handler.fs.Open(filepath.Join(handler.rootfsRoot, configPath))
+		if err != nil {
+			handler.log.Printf("Error opening the irqbalance configuration: %v", err)
+			continue
+		}
+		vars, err := ParseConfig(src)
+		src.Close()
+		if err != nil {
+			handler.log.Printf("Error parsing the irqbalance configuration %q: %v", configPath, err)
+			continue
+		}
+		config := handler.makeSettings(vars)
+		info.ConfigPath = configPath
+		info.Config = &config
+		break
+	}
+
+	if info.Running() {
+		vars, err := handler.readEnviron(info.PIDs[0])
+		if err != nil {
+			handler.log.Printf("Error reading the irqbalance environment: %v", err)
+		} else {
+			runtime := handler.makeSettings(vars)
+			info.Runtime = &runtime
+		}
+	}
+
+	if script := info.Effective().PolicyScript; script != "" {
+		_, err := handler.fs.ReadFile(filepath.Join(handler.rootfsRoot, script))
+		info.PolicyScriptFound = err == nil
+	}
+	return info, nil
+}
+
+func (handler *Handler) findDaemons() ([]int, error) {
+	entries, err := handler.fs.ReadDir(handler.procfsRoot)
+	if err != nil {
+		return nil, err
+	}
+	var pids []int
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue // not a process
+		}
+		comm, err := handler.fs.ReadFile(filepath.Join(handler.procfsRoot, entry.Name(), "comm"))
+		if err != nil {
+			// the process can be gone meantime
+			continue
+		}
+		if strings.TrimSpace(string(comm)) == "irqbalance" {
+			pids = append(pids, pid)
+		}
+	}
+	sort.Ints(pids)
+	return pids, nil
+}
+
+func (handler *Handler) readEnviron(pid int) (map[string]string, error) {
+	data, err := handler.fs.ReadFile(filepath.Join(handler.procfsRoot, strconv.Itoa(pid), "environ"))
+	if err != nil {
+		return nil, err
+	}
+	vars := make(map[string]string)
+	for _, item := range strings.Split(string(data), "\x00") {
+		key, val, ok := strings.Cut(item, "=")
+		if !ok {
+			continue
+		}
+		vars[key] = val
+	}
+	return vars, nil
+}
+
+func (handler *Handler) makeSettings(vars map[string]string) Settings {
+	st := Settings{
+		Args:    vars[VarArgs],
+		OneShot: vars[VarOneShot] != "",
+	}
+	st.PolicyScript = parsePolicyScript(st.Args)
+	if val, ok := vars[VarBannedCPUs]; ok {
+		cpus, err := cpumask.Parse(val)
+		if err != nil {
+			handler.log.Printf("Error parsing %s: %v", VarBannedCPUs, err)
+		} else {
+			st.BannedCPUs = &cpus
+		}
+	}
+	if val, ok := vars[VarBannedCPUList]; ok {
+		cpus, err := cpuset.Parse(strings.TrimSpace(val))
+		if err != nil {
+			handler.log.Printf("Error parsing %s: %v", VarBannedCPUList, err)
+		} else {
+			st.BannedCPUList = &cpus
+		}
+	}
+	return st
+}
+
+// parsePolicyScript finds the policy script in the irqbalance arguments, either "--policyscript=PATH" or "-l PATH"
+func parsePolicyScript(args string) string {
+	items := strings.Fields(args)
+	for idx, item := range items {
+		if val, ok := strings.CutPrefix(item, "--policyscript="); ok {
+			return val
+		}
+		if (item == "--policyscript" || item == "-l") && idx+1 < len(items) {
+			return items[idx+1]
+		}
+	}
+	return ""
+}
+
+// ParseConfig parses the shell-like variable assignments of the irqbalance configuration files.
+func ParseConfig(r io.Reader) (map[string]string, error) {
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		val = strings.TrimSpace(val)
+		if len(val) >= 2 && (val[0] == '"' || val[0] == '\'') && val[len(val)-1] == val[0] {
+			val = val[1 : len(val)-1]
+		} else if idx := strings.Index(val, " #"); idx >= 0 {
+			// trailing comments are allowed only after unquoted values
+			val = strings.TrimSpace(val[:idx])
+		}
+		vars[strings.TrimSpace(key)] = val
+	}
+	return vars, scanner.Err()
+}
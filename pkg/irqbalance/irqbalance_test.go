@@ -0,0 +1,126 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqbalance_test
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/internal/fakefs"
+	"github.com/openshift-kni/debug-tools/pkg/irqbalance"
+)
+
+var nullLog = log.New(ioutil.Discard, "", 0)
+
+const fakeSysconfig = `# irqbalance is a daemon process that distributes interrupts across
+# CPUS on SMP systems.
+#IRQBALANCE_ONESHOT=
+
+# IRQBALANCE_BANNED_CPUS
+# 64 bit bitmask which allows you to indicate which cpu's should
+# be skipped when reblancing irqs.
+IRQBALANCE_BANNED_CPUS="0000000c"
+
+IRQBALANCE_ARGS=--policyscript=/etc/irqbalance.sh # set by the admin
+`
+
+func TestParseConfig(t *testing.T) {
+	vars, err := irqbalance.ParseConfig(strings.NewReader(fakeSysconfig + "export IRQBALANCE_BANNED_CPULIST='4-5'\n"))
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+	expected := map[string]string{
+		irqbalance.VarBannedCPUs:    "0000000c",
+		irqbalance.VarArgs:          "--policyscript=/etc/irqbalance.sh",
+		irqbalance.VarBannedCPUList: "4-5",
+	}
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("unexpected vars\ngot=%v\nexpected=%v", vars, expected)
+	}
+}
+
+func TestReadInfo(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(rootDir) // clean up
+
+	procDir := filepath.Join(rootDir, "proc")
+	rootfsDir := filepath.Join(rootDir, "host")
+	fakefs.WriteFile(t, filepath.Join(procDir, "1", "comm"), "systemd\n")
+	fakefs.WriteFile(t, filepath.Join(procDir, "ABC", "comm"), "irqbalance\n")
+	fakefs.WriteFile(t, filepath.Join(rootfsDir, "etc", "sysconfig", "irqbalance"), fakeSysconfig)
+
+	ih := irqbalance.New(nullLog, procDir, rootfsDir)
+	info, err := ih.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo failed: %v", err)
+	}
+	if info.Running() {
+		t.Errorf("irqbalance unexpectedly running: %v", info.PIDs)
+	}
+	if info.ConfigPath != "etc/sysconfig/irqbalance" || info.Config == nil {
+		t.Fatalf("configuration not found: %+v", info)
+	}
+	if banned := info.Effective().Banned(); !banned.Equals(cpuset.New(2, 3)) {
+		t.Errorf("unexpected banned cpus: %v", banned)
+	}
+	if writer := info.LikelyWriter(cpuset.New(0, 1)); !strings.HasPrefix(writer, "unknown") {
+		t.Errorf("unexpected writer: %q", writer)
+	}
+
+	// the daemon started before the configuration was changed
+	fakefs.WriteFile(t, filepath.Join(procDir, "1234", "comm"), "irqbalance\n")
+	fakefs.WriteFile(t, filepath.Join(procDir, "1234", "environ"), "PATH=/usr/bin\x00IRQBALANCE_BANNED_CPUS=00000008\x00")
+
+	info, err = ih.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo failed: %v", err)
+	}
+	if !reflect.DeepEqual(info.PIDs, []int{1234}) {
+		t.Fatalf("unexpected pids: %v", info.PIDs)
+	}
+	if info.Runtime == nil {
+		t.Fatalf("missing runtime settings")
+	}
+	if banned := info.Effective().Banned(); !banned.Equals(cpuset.New(3)) {
+		t.Errorf("unexpected banned cpus: %v", banned)
+	}
+
+	var writerTestCases = []struct {
+		cpus     cpuset.CPUSet
+		expected string
+	}{
+		{cpuset.New(0, 1, 2), "irqbalance (pid 1234)"},
+		{cpuset.New(3), "unknown, irqbalance (pid 1234) would not use its banned cpus 3"},
+	}
+	for _, tt := range writerTestCases {
+		t.Run(tt.cpus.String(), func(t *testing.T) {
+			if writer := info.LikelyWriter(tt.cpus); writer != tt.expected {
+				t.Errorf("got %q expected %q", writer, tt.expected)
+			}
+		})
+	}
+}
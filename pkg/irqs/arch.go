@@ -0,0 +1,136 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqs
+
+import (
+	"strings"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+)
+
+// kinds of the architecture-specific interrupts, which are the non-numeric rows of /proc/interrupts
+const (
+	ArchKindIPI     = "ipi"
+	ArchKindTimer   = "timer"
+	ArchKindPerf    = "perf"
+	ArchKindWork    = "work"
+	ArchKindHW      = "hardware"
+	ArchKindVirt    = "virt"
+	ArchKindUnknown = "unknown"
+)
+
+type ArchInterrupt struct {
+	Kind string `json:"kind"`
+	// Cause is what usually triggers the interrupt
+	Cause string `json:"cause"`
+}
+
+// see arch/x86/kernel/irq.c:arch_show_interrupts
+var archInterrupts = map[string]ArchInterrupt{
+	"NMI": {ArchKindPerf, "non-maskable interrupts, from the perf counters overflow and the hard lockup watchdog"},
+	"LOC": {ArchKindTimer, "local timer ticks, expected to stop on nohz_full cpus running a single task"},
+	"SPU": {ArchKindHW, "spurious interrupts"},
+	"PMI": {ArchKindPerf, "performance monitoring interrupts, from perf sampling"},
+	"IWI": {ArchKindWork, "irq work interrupts, from deferred work queued by perf, printk or cpufreq"},
+	"RTR": {ArchKindHW, "APIC ICR read retries"},
+	"PLT": {ArchKindHW, "platform interrupts"},
+	"RES": {ArchKindIPI, "reschedule IPIs, from wakeups of tasks on this cpu and from the load balancer"},
+	"CAL": {ArchKindIPI, "function call IPIs, from smp_call_function users like cpufreq, membarrier or per-cpu cache drains"},
+	"TLB": {ArchKindIPI, "TLB shootdowns, from munmap, mprotect or page migration in processes with threads on this cpu"},
+	"TRM": {ArchKindHW, "thermal event interrupts"},
+	"THR": {ArchKindHW, "threshold APIC interrupts, from corrected machine check errors"},
+	"DFR": {ArchKindHW, "deferred error APIC interrupts"},
+	"MCE": {ArchKindHW, "machine check exceptions"},
+	"MCP": {ArchKindHW, "machine check polls, periodic unless disabled with mce=ignore_ce"},
+	"HYP": {ArchKindVirt, "hypervisor callback interrupts"},
+	"HRE": {ArchKindVirt, "Hyper-V reenlightenment interrupts"},
+	"HVS": {ArchKindVirt, "Hyper-V stimer0 interrupts"},
+	"PIN": {ArchKindVirt, "posted-interrupt notifications, from KVM guests"},
+	"NPI": {ArchKindVirt, "nested posted-interrupt events, from KVM guests"},
+	"PIW": {ArchKindVirt, "posted-interrupt wakeups, from KVM guests"},
+}
+
+// other architectures name the rows differently (e.g. arm64 uses IPI0..IPI6), so we fall back to the labels
+var archInterruptLabels = []struct {
+	prefix string
+	name   string
+}{
+	{"Rescheduling interrupts", "RES"},
+	{"Function call interrupts", "CAL"},
+	{"TLB shootdowns", "TLB"},
+	{"IRQ work interrupts", "IWI"},
+	{"Machine check exceptions", "MCE"},
+}
+
+// ClassifyArch classifies the architecture-specific interrupt `name`, using its /proc/interrupts `label`
+// if the name is not known. Returns false for device IRQs.
+func ClassifyArch(name, label string) (ArchInterrupt, bool) {
+	if isNumber(name) {
+		return ArchInterrupt{}, false
+	}
+	if ai, ok := archInterrupts[name]; ok {
+		return ai, true
+	}
+	for _, al := range archInterruptLabels {
+		if strings.HasPrefix(label, al.prefix) {
+			return archInterrupts[al.name], true
+		}
+	}
+	return ArchInterrupt{
+		Kind:  ArchKindUnknown,
+		Cause: label,
+	}, true
+}
+
+type ArchUsage struct {
+	CPU   int    `json:"cpu"`
+	Name  string `json:"name"`
+	Label string `json:"label,omitempty"`
+	ArchInterrupt
+	Count uint64  `json:"count"`
+	Rate  float64 `json:"rate"`
+}
+
+// AnalyzeArch returns the architecture-specific interrupts the `cpus` received, as computed in the `delta`
+// over the `elapsed` time. The result is sorted by cpu and then by name.
+func AnalyzeArch(cpus cpuset.CPUSet, delta Stats, descs Descriptions, elapsed time.Duration) []ArchUsage {
+	var res []ArchUsage
+	for _, cpuid := range cpus.List() {
+		counter := delta[cpuid]
+		for _, name := range sortedNames(counter) {
+			val := counter[name]
+			if val == 0 {
+				continue
+			}
+			label := descs[name].Label
+			ai, ok := ClassifyArch(name, label)
+			if !ok {
+				continue
+			}
+			res = append(res, ArchUsage{
+				CPU:           cpuid,
+				Name:          name,
+				Label:         label,
+				ArchInterrupt: ai,
+				Count:         val,
+				Rate:          rate(val, elapsed),
+			})
+		}
+	}
+	return res
+}
@@ -0,0 +1,94 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqs_test
+
+import (
+	"testing"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
+)
+
+func TestClassifyArch(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		label    string
+		kind     string
+		expected bool
+	}{
+		{"RES", "Rescheduling interrupts", irqs.ArchKindIPI, true},
+		{"TLB", "TLB shootdowns", irqs.ArchKindIPI, true},
+		{"LOC", "Local timer interrupts", irqs.ArchKindTimer, true},
+		{"NMI", "Non-maskable interrupts", irqs.ArchKindPerf, true},
+		{"IPI1", "Function call interrupts", irqs.ArchKindIPI, true},
+		{"IPI3", "CPU stop (for crash dump) interrupts", irqs.ArchKindUnknown, true},
+		{"25", "", "", false},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ai, ok := irqs.ClassifyArch(tt.name, tt.label)
+			if ok != tt.expected {
+				t.Fatalf("unexpected classification status: %v", ok)
+			}
+			if ai.Kind != tt.kind {
+				t.Errorf("got kind %q expected %q", ai.Kind, tt.kind)
+			}
+		})
+	}
+}
+
+func TestAnalyzeArch(t *testing.T) {
+	delta := irqs.Stats{
+		1: irqs.Counter{"RES": 100},
+		2: irqs.Counter{"LOC": 20, "RES": 4, "CAL": 0, "153": 10},
+		3: irqs.Counter{"TLB": 2, "LOC": 10},
+	}
+	descs := irqs.Descriptions{
+		"LOC": {Label: "Local timer interrupts"},
+		"RES": {Label: "Rescheduling interrupts"},
+		"CAL": {Label: "Function call interrupts"},
+		"TLB": {Label: "TLB shootdowns"},
+		"153": {Chip: "IR-PCI-MSI", Actions: []string{"ens1f0-TxRx-1"}},
+	}
+
+	usage := irqs.AnalyzeArch(cpuset.New(2, 3), delta, descs, 2*time.Second)
+	expected := []struct {
+		cpu   int
+		name  string
+		count uint64
+		rate  float64
+	}{
+		{2, "LOC", 20, 10},
+		{2, "RES", 4, 2},
+		{3, "LOC", 10, 5},
+		{3, "TLB", 2, 1},
+	}
+	if len(usage) != len(expected) {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+	for idx, exp := range expected {
+		au := usage[idx]
+		if au.CPU != exp.cpu || au.Name != exp.name || au.Count != exp.count || au.Rate != exp.rate {
+			t.Errorf("usage #%d: got %+v expected %+v", idx, au, exp)
+		}
+		if au.Label != descs[exp.name].Label || au.Cause == "" {
+			t.Errorf("usage #%d: missing description: %+v", idx, au)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqs
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+)
+
+const (
+	// GroupByNone reports each counter on each cpu
+	GroupByNone = ""
+	// GroupByCPU reports the total of all the counters on each cpu
+	GroupByCPU = "cpu"
+	// GroupByIRQ reports the total of each counter across all the cpus
+	GroupByIRQ = "irq"
+)
+
+func ValidateGroupBy(groupBy string) error {
+	switch groupBy {
+	case GroupByNone, GroupByCPU, GroupByIRQ:
+		return nil
+	}
+	return fmt.Errorf("unsupported grouping %q, must be one of: %s, %s", groupBy, GroupByCPU, GroupByIRQ)
+}
+
+type BreakdownEntry struct {
+	CPUs []int `json:"cpus"`
+	// Name is empty when grouping by cpu
+	Name string `json:"name,omitempty"`
+	// Source is the resolved device or action name, if known
+	Source string  `json:"source,omitempty"`
+	Count  uint64  `json:"count"`
+	Rate   float64 `json:"rate"`
+}
+
+// Breakdown aggregates the non-zero `delta` counters of the `cpus` as `groupBy` requires. If `top` is positive,
+// only the first `top` entries by decreasing count are returned. Since all the entries share the same `elapsed` time,
+// sorting by count also sorts by rate. Entries with the same count, or all of them when neither grouping
+// nor top counters are requested, are sorted by cpu and then by name.
+func Breakdown(cpus cpuset.CPUSet, delta Stats, groupBy string, top int, sources map[string]string, elapsed time.Duration) []BreakdownEntry {
+	var res []BreakdownEntry
+	switch groupBy {
+	case GroupByCPU:
+		for _, cpuid := range cpus.List() {
+			var total uint64
+			for _, val := range delta[cpuid] {
+				total += val
+			}
+			if total == 0 {
+				continue
+			}
+			res = append(res, BreakdownEntry{CPUs: []int{cpuid}, Count: total})
+		}
+	case GroupByIRQ:
+		totals := make(Counter)
+		irqCPUs := make(map[string][]int)
+		for _, cpuid := range cpus.List() {
+			for name, val := range delta[cpuid] {
+				if val == 0 {
+					continue
+				}
+				totals[name] += val
+				irqCPUs[name] = append(irqCPUs[name], cpuid)
+			}
+		}
+		for _, name := range sortedNames(totals) {
+			res = append(res, BreakdownEntry{CPUs: irqCPUs[name], Name: name, Source: sources[name], Count: totals[name]})
+		}
+	default:
+		for _, cpuid := range cpus.List() {
+			counter := delta[cpuid]
+			for _, name := range sortedNames(counter) {
+				if counter[name] == 0 {
+					continue
+				}
+				res = append(res, BreakdownEntry{CPUs: []int{cpuid}, Name: name, Source: sources[name], Count: counter[name]})
+			}
+		}
+	}
+
+	if groupBy != GroupByNone || top > 0 {
+		// entries are already sorted by cpu and name
+		sort.SliceStable(res, func(i, j int) bool {
+			return res[i].Count > res[j].Count
+		})
+	}
+	if top > 0 && len(res) > top {
+		res = res[:top]
+	}
+	for idx := range res {
+		res[idx].Rate = rate(res[idx].Count, elapsed)
+	}
+	return res
+}
+
+// Sources resolves the IRQ names to the device and action names which make sense to humans.
+// `owners` optionally maps the IRQ numbers to the PCI device addresses.
+func Sources(descs Descriptions, owners map[int]string) map[string]string {
+	res := make(map[string]string)
+	for name, desc := range descs {
+		if desc.Label != "" {
+			res[name] = desc.Label
+			continue
+		}
+		source := strings.Join(desc.Actions, ", ")
+		if irq, err := strconv.Atoi(name); err == nil {
+			if addr, ok := owners[irq]; ok {
+				if source == "" {
+					source = addr
+				} else {
+					source = fmt.Sprintf("%s (%s)", source, addr)
+				}
+			}
+		}
+		if source != "" {
+			res[name] = source
+		}
+	}
+	return res
+}
@@ -0,0 +1,144 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqs_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
+)
+
+var fakeBreakdownDelta = irqs.Stats{
+	0: irqs.Counter{"LOC": 900, "RES": 3},
+	2: irqs.Counter{"LOC": 100, "153": 40, "CAL": 0},
+	3: irqs.Counter{"LOC": 100, "153": 2},
+}
+
+func TestBreakdown(t *testing.T) {
+	cpus := cpuset.New(0, 2, 3)
+	sources := map[string]string{
+		"153": "ens1f0-TxRx-1 (0000:3b:00.0)",
+	}
+
+	var testCases = []struct {
+		name     string
+		groupBy  string
+		top      int
+		expected []irqs.BreakdownEntry
+	}{
+		{
+			name:    "none",
+			groupBy: irqs.GroupByNone,
+			expected: []irqs.BreakdownEntry{
+				{CPUs: []int{0}, Name: "LOC", Count: 900, Rate: 450},
+				{CPUs: []int{0}, Name: "RES", Count: 3, Rate: 1.5},
+				{CPUs: []int{2}, Name: "153", Source: sources["153"], Count: 40, Rate: 20},
+				{CPUs: []int{2}, Name: "LOC", Count: 100, Rate: 50},
+				{CPUs: []int{3}, Name: "153", Source: sources["153"], Count: 2, Rate: 1},
+				{CPUs: []int{3}, Name: "LOC", Count: 100, Rate: 50},
+			},
+		},
+		{
+			name:    "top",
+			groupBy: irqs.GroupByNone,
+			top:     3,
+			expected: []irqs.BreakdownEntry{
+				{CPUs: []int{0}, Name: "LOC", Count: 900, Rate: 450},
+				{CPUs: []int{2}, Name: "LOC", Count: 100, Rate: 50},
+				{CPUs: []int{3}, Name: "LOC", Count: 100, Rate: 50},
+			},
+		},
+		{
+			name:    "cpu",
+			groupBy: irqs.GroupByCPU,
+			expected: []irqs.BreakdownEntry{
+				{CPUs: []int{0}, Count: 903, Rate: 451.5},
+				{CPUs: []int{2}, Count: 140, Rate: 70},
+				{CPUs: []int{3}, Count: 102, Rate: 51},
+			},
+		},
+		{
+			name:    "irq",
+			groupBy: irqs.GroupByIRQ,
+			top:     2,
+			expected: []irqs.BreakdownEntry{
+				{CPUs: []int{0, 2, 3}, Name: "LOC", Count: 1100, Rate: 550},
+				{CPUs: []int{2, 3}, Name: "153", Source: sources["153"], Count: 42, Rate: 21},
+			},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := irqs.Breakdown(cpus, fakeBreakdownDelta, tt.groupBy, tt.top, sources, 2*time.Second)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("unexpected breakdown\ngot=%+v\nexpected=%+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSources(t *testing.T) {
+	descs := irqs.Descriptions{
+		"LOC": {Label: "Local timer interrupts"},
+		"153": {Chip: "IR-PCI-MSI", HWIRQ: "1572865", Actions: []string{"ens1f0-TxRx-1"}},
+		"16":  {Chip: "IR-IO-APIC", HWIRQ: "16", Actions: []string{"i801_smbus", "ehci_hcd:usb1"}},
+		"200": {Chip: "IR-PCI-MSI"},
+	}
+	owners := map[int]string{
+		153: "0000:3b:00.0",
+		200: "0000:5e:00.0",
+	}
+	expected := map[string]string{
+		"LOC": "Local timer interrupts",
+		"153": "ens1f0-TxRx-1 (0000:3b:00.0)",
+		"16":  "i801_smbus, ehci_hcd:usb1",
+		"200": "0000:5e:00.0",
+	}
+	if got := irqs.Sources(descs, owners); !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected sources\ngot=%v\nexpected=%v", got, expected)
+	}
+}
+
+func TestReportingGroupByIRQText(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := irqs.NewReporterWithOptions(&buf, irqs.ReporterOptions{
+		Verbose: 1,
+		CPUs:    cpuset.New(0, 2, 3),
+		GroupBy: irqs.GroupByIRQ,
+		Sources: map[string]string{"153": "ens1f0-TxRx-1"},
+	})
+	reporter.Summary(time.Now(), irqs.Stats{}, fakeBreakdownDelta)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		"IRQ=LOC +1100 on cpus [0 2 3]",
+		"IRQ=153 +42 on cpus [2 3] [ens1f0-TxRx-1]",
+		"IRQ=RES +3 on cpus [0]",
+	}
+	if len(lines) != len(expected)+1 {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+	if !reflect.DeepEqual(lines[1:], expected) {
+		t.Errorf("unexpected lines\ngot=%q\nexpected=%q", lines[1:], expected)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqs
+
+import (
+	"fmt"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+)
+
+const (
+	// AffinityKindRequested is the affinity from smp_affinity_list
+	AffinityKindRequested = "affinity"
+	// AffinityKindEffective is the affinity from effective_affinity_list
+	AffinityKindEffective = "effective"
+)
+
+// AffinityChange is a change of the affinity of an IRQ observed between two reads.
+// The affinities are in the cpulist format.
+type AffinityChange struct {
+	Timestamp time.Time `json:"timestamp"`
+	Kind      string    `json:"kind"`
+	Change
+}
+
+func (ac AffinityChange) String() string {
+	return fmt.Sprintf("%v %-9s %s", ac.Timestamp, ac.Kind, ac.Change.String())
+}
+
+// DiffAffinity returns the changes of the affinities of the IRQs found both in `prev` and `last`,
+// in the same order as `last`, the requested affinity first.
+func DiffAffinity(ts time.Time, prev, last []Info) []AffinityChange {
+	prevInfos := make(map[int]Info)
+	for _, info := range prev {
+		prevInfos[info.IRQ] = info
+	}
+
+	var res []AffinityChange
+	for _, info := range last {
+		prevInfo, ok := prevInfos[info.IRQ]
+		if !ok {
+			continue
+		}
+		if !prevInfo.AffinityCPUs.Equals(info.AffinityCPUs) {
+			res = append(res, newAffinityChange(ts, info, AffinityKindRequested, prevInfo.AffinityCPUs, info.AffinityCPUs))
+		}
+		if !prevInfo.EffectiveCPUs.Equals(info.EffectiveCPUs) {
+			res = append(res, newAffinityChange(ts, info, AffinityKindEffective, prevInfo.EffectiveCPUs, info.EffectiveCPUs))
+		}
+	}
+	return res
+}
+
+func newAffinityChange(ts time.Time, info Info, kind string, from, to cpuset.CPUSet) AffinityChange {
+	return AffinityChange{
+		Timestamp: ts,
+		Kind:      kind,
+		Change: Change{
+			IRQ:    info.IRQ,
+			Source: info.Source,
+			From:   from.String(),
+			To:     to.String(),
+		},
+	}
+}
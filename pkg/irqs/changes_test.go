@@ -0,0 +1,51 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqs_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
+)
+
+func TestDiffAffinity(t *testing.T) {
+	ts := time.Date(2026, time.October, 17, 10, 0, 0, 0, time.UTC)
+	prev := []irqs.Info{
+		{IRQ: 0, Source: "timer", AffinityCPUs: cpuset.New(0, 1, 2, 3), EffectiveCPUs: cpuset.New(0)},
+		{IRQ: 153, Source: "ens1f0-TxRx-1", AffinityCPUs: cpuset.New(2), EffectiveCPUs: cpuset.New(2)},
+		{IRQ: 154, Source: "ens1f0-TxRx-2", AffinityCPUs: cpuset.New(3), EffectiveCPUs: cpuset.New(3)},
+	}
+	last := []irqs.Info{
+		{IRQ: 0, Source: "timer", AffinityCPUs: cpuset.New(0, 1, 2, 3), EffectiveCPUs: cpuset.New(0)},
+		{IRQ: 153, Source: "ens1f0-TxRx-1", AffinityCPUs: cpuset.New(0, 1), EffectiveCPUs: cpuset.New(1)},
+		{IRQ: 154, Source: "ens1f0-TxRx-2", AffinityCPUs: cpuset.New(3), EffectiveCPUs: cpuset.New(3)},
+		{IRQ: 155, Source: "ens1f0-TxRx-3", AffinityCPUs: cpuset.New(1), EffectiveCPUs: cpuset.New(1)},
+	}
+
+	changes := irqs.DiffAffinity(ts, prev, last)
+	expected := []irqs.AffinityChange{
+		{Timestamp: ts, Kind: irqs.AffinityKindRequested, Change: irqs.Change{IRQ: 153, Source: "ens1f0-TxRx-1", From: "2", To: "0-1"}},
+		{Timestamp: ts, Kind: irqs.AffinityKindEffective, Change: irqs.Change{IRQ: 153, Source: "ens1f0-TxRx-1", From: "2", To: "1"}},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("unexpected changes\ngot=%+v\nexpected=%+v", changes, expected)
+	}
+}
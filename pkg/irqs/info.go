@@ -32,6 +32,11 @@ import (
 
 const (
 	EffectiveAffinity = 1 << iota
+	// DetectManaged enables the detection of the kernel-managed IRQs, see Info.Managed
+	DetectManaged
+	// ProbeManaged lets DetectManaged fall back to a write probe if debugfs is not available.
+	// The probe writes smp_affinity, so it must not be used by read-only commands.
+	ProbeManaged
 )
 
 // the IRQ name is not always a number, can be like "NMI", "TLB"...
@@ -77,15 +82,52 @@ func (S Stats) Clone() Stats {
 	return R
 }
 
+// Description is what /proc/interrupts reports about an IRQ past the per-CPU counters.
+// For device IRQs, the line looks like
+// "  25:  0 ... 0  IR-PCI-MSI 458752-edge      PCIe PME, pciehp"
+// while for the architecture-specific interrupts, the line looks like
+// " LOC:  14926901 ... 15519974   Local timer interrupts"
+type Description struct {
+	// Chip is the name of the interrupt controller, like "IR-PCI-MSI" or "GICv3"
+	Chip string `json:"chip,omitempty"`
+	// HWIRQ is the interrupt number in the controller domain
+	HWIRQ string `json:"hwirq,omitempty"`
+	// Trigger is the flow handler name (like "edge" or "fasteoi") or the trigger level (like "Level")
+	Trigger string `json:"trigger,omitempty"`
+	// Actions are the names of the handlers registered for the IRQ
+	Actions []string `json:"actions,omitempty"`
+	// Label is the human readable name of the architecture-specific interrupts, like "Local timer interrupts"
+	Label string `json:"label,omitempty"`
+}
+
+// IRQ name -> description
+type Descriptions map[string]Description
+
 type Info struct {
 	Source string
 	IRQ    int
-	CPUs   cpuset.CPUSet
+	// CPUs is either AffinityCPUs or EffectiveCPUs, depending on the flags passed to ReadInfo
+	CPUs cpuset.CPUSet
+	// AffinityCPUs is the requested affinity, from smp_affinity_list
+	AffinityCPUs cpuset.CPUSet
+	// EffectiveCPUs is the affinity the kernel actually enforces, from effective_affinity_list.
+	// Older kernels don't report it, in that case it is the same as AffinityCPUs.
+	EffectiveCPUs cpuset.CPUSet
+	// Managed is true if the kernel manages the IRQ affinity, so it cannot be changed from userspace.
+	// It is only set when confirmed by debugfs.
+	Managed bool
+	// Unmovable is true if the IRQ affinity cannot be changed from userspace. Managed IRQs are unmovable,
+	// but the write probe can also find unmovable IRQs which are not managed, like the per-CPU ones.
+	Unmovable bool
+	// ManagedCheck tells how Managed and Unmovable were detected (see the ManagedCheck* constants), empty if detection was not possible or not requested.
+	ManagedCheck string
+	Description
 }
 
 type Handler struct {
 	log        *log.Logger
 	procfsRoot string
+	sysfsRoot  string
 	fs         fswrap.FSWrapper
 }
 
@@ -97,6 +139,13 @@ func New(logger *log.Logger, procfsRoot string) *Handler {
 	}
 }
 
+// NewWithSysFS also sets the sysfs root, used to look for the IRQ debug information in debugfs.
+func NewWithSysFS(logger *log.Logger, procfsRoot, sysfsRoot string) *Handler {
+	handler := New(logger, procfsRoot)
+	handler.sysfsRoot = sysfsRoot
+	return handler
+}
+
 func (handler *Handler) ReadInfo(flags uint) ([]Info, error) {
 	// the best source of information here is man 5 procfs
 	// and https://www.kernel.org/doc/Documentation/IRQ-affinity.txt
@@ -118,45 +167,80 @@ func (handler *Handler) ReadInfo(flags uint) ([]Info, error) {
 
 	sort.Ints(irqs)
 
-	affinityListFile := "smp_affinity_list"
-	if (flags & EffectiveAffinity) == EffectiveAffinity {
-		affinityListFile = "effective_affinity_list"
+	// failures are not critical, we just lose the fallback source names
+	_, descs, err := handler.ReadInterrupts()
+	if err != nil {
+		handler.log.Printf("Error reading the IRQ descriptions: %v", err)
 	}
 
-	irqInfos := make([]Info, len(irqs))
+	useEffective := (flags & EffectiveAffinity) == EffectiveAffinity
+
+	irqInfos := make([]Info, 0, len(irqs))
 	for _, irq := range irqs {
 		irqDir := filepath.Join(irqRoot, fmt.Sprintf("%d", irq))
 
-		irqCpuList, err := handler.fs.ReadFile(filepath.Join(irqDir, affinityListFile))
+		affinityCpuList, err := handler.fs.ReadFile(filepath.Join(irqDir, "smp_affinity_list"))
 		if err != nil {
 			return nil, err
 		}
-
-		irqCpus, err := cpuset.Parse(strings.TrimSpace(string(irqCpuList)))
+		affinityCpus, err := cpuset.Parse(strings.TrimSpace(string(affinityCpuList)))
 		if err != nil {
-			handler.log.Printf("Error parsing cpulist in %q: %v", irqCpuList, err)
+			handler.log.Printf("Error parsing cpulist in %q: %v", affinityCpuList, err)
 			continue // keep running
 		}
 
-		irqInfos = append(irqInfos, Info{
-			CPUs:   irqCpus,
-			IRQ:    irq,
-			Source: handler.findSourceForIRQ(irq),
-		})
+		// older kernels don't report the effective affinity
+		effectiveCpus := affinityCpus
+		effectiveCpuList, err := handler.fs.ReadFile(filepath.Join(irqDir, "effective_affinity_list"))
+		if err != nil && useEffective {
+			return nil, err
+		}
+		if err == nil {
+			effectiveCpus, err = cpuset.Parse(strings.TrimSpace(string(effectiveCpuList)))
+			if err != nil {
+				handler.log.Printf("Error parsing cpulist in %q: %v", effectiveCpuList, err)
+				continue // keep running
+			}
+		}
+
+		irqCpus := affinityCpus
+		if useEffective {
+			irqCpus = effectiveCpus
+		}
+
+		desc := descs[strconv.Itoa(irq)]
+		info := Info{
+			CPUs:          irqCpus,
+			AffinityCPUs:  affinityCpus,
+			EffectiveCPUs: effectiveCpus,
+			IRQ:           irq,
+			Source:        handler.findSourceForIRQ(irq, desc),
+			Description:   desc,
+		}
+		if (flags & DetectManaged) == DetectManaged {
+			info.Managed, info.Unmovable, info.ManagedCheck = handler.detectManaged(irq, (flags&ProbeManaged) == ProbeManaged)
+		}
+		irqInfos = append(irqInfos, info)
 	}
 	return irqInfos, nil
 }
 
 func (handler *Handler) ReadStats() (Stats, error) {
+	stats, _, err := handler.ReadInterrupts()
+	return stats, err
+}
+
+// ReadInterrupts returns both the per-CPU counters and the descriptions of all the IRQs
+func (handler *Handler) ReadInterrupts() (Stats, Descriptions, error) {
 	src, err := handler.fs.Open(filepath.Join(handler.procfsRoot, "interrupts"))
 	if err != nil {
-		return nil, fmt.Errorf("error reading interrupts from %q: %v", handler.procfsRoot, err)
+		return nil, nil, fmt.Errorf("error reading interrupts from %q: %v", handler.procfsRoot, err)
 	}
 	defer src.Close()
 	return parseInterrupts(handler.log, src)
 }
 
-func parseInterrupts(logger *log.Logger, rd io.Reader) (Stats, error) {
+func parseInterrupts(logger *log.Logger, rd io.Reader) (Stats, Descriptions, error) {
 	src := bufio.NewScanner(rd)
 	src.Scan()
 	cpus := strings.Fields(src.Text())
@@ -173,16 +257,18 @@ func parseInterrupts(logger *log.Logger, rd io.Reader) (Stats, error) {
 		var cpuid int
 		n, err := fmt.Sscanf(cpu, "CPU%d", &cpuid)
 		if n != 1 || err != nil {
-			return nil, fmt.Errorf("cannot parse cpu name %q: err=%v", cpu, err)
+			return nil, nil, fmt.Errorf("cannot parse cpu name %q: err=%v", cpu, err)
 		}
 		stats[cpuid] = make(Counter)
 		// if all the cpus are online, this is the trivial mapping 0:0, 1:1, ...
 		col2cpu[colIdx] = cpuid
 	}
 
+	descs := make(Descriptions)
+
 	// format:
-	// IRQ: cpu0_counter ... cpuN_counter [stuff we dont care]
-	// so we need to scan only the first len(cpus) + 1 columns
+	// IRQ: cpu0_counter ... cpuN_counter [description]
+	// so we need to scan only the first len(cpus) + 1 columns for the counters
 	maxCols := 1 + len(cpus)
 	for src.Scan() {
 		items := strings.Fields(src.Text())
@@ -194,7 +280,7 @@ func parseInterrupts(logger *log.Logger, rd io.Reader) (Stats, error) {
 		// so from now on we consider only len(cpus) columns, shifted by one to the left
 		//                [ "0:" "13" "0" "0" "0" "IR-IO-APIC" "2-edge" "timer"]
 		// column index:    0    1    2   3   4   5            6        7
-		//                  |----+                ============================ we don't care about this
+		//                  |----+                ============================ this is the description
 		//                       |----|---|---|
 		//                                    `- we only care about 1 + len(cpus) = 4 = 5 columns
 		// `cpuColIdx`:    {skip} 0   1   2   3
@@ -208,23 +294,83 @@ func parseInterrupts(logger *log.Logger, rd io.Reader) (Stats, error) {
 			cpuId := col2cpu[cpuColIdx]
 			stats[cpuId][irqName] = count
 		}
+		descs[irqName] = parseDescription(irqName, items[maxCols:])
 	}
-	return stats, nil
+	return stats, descs, nil
 }
 
-// TODO: we may want to crosscorrelate with `/proc/interrupts, which always give a valid (!= "") source
-func (handler *Handler) findSourceForIRQ(irq int) string {
+// parseDescription parses the trailing columns of a /proc/interrupts line.
+// See kernel/irq/proc.c:show_interrupts for the format.
+func parseDescription(irqName string, items []string) Description {
+	desc := Description{}
+	if len(items) == 0 {
+		return desc
+	}
+	if _, err := strconv.Atoi(irqName); err != nil {
+		// architecture-specific interrupts only have a label
+		desc.Label = strings.Join(items, " ")
+		return desc
+	}
+
+	desc.Chip = items[0]
+	items = items[1:]
+	if len(items) > 0 {
+		// modern kernels report "hwirq-flowhandler", like "458752-edge"
+		if hwirq, trigger, ok := strings.Cut(items[0], "-"); ok && isNumber(hwirq) {
+			desc.HWIRQ = hwirq
+			desc.Trigger = trigger
+			items = items[1:]
+		} else if isNumber(items[0]) {
+			// with GENERIC_IRQ_SHOW_LEVEL, like "27 Level"
+			desc.HWIRQ = items[0]
+			items = items[1:]
+			if len(items) > 0 && (items[0] == "Level" || items[0] == "Edge") {
+				desc.Trigger = items[0]
+				items = items[1:]
+			}
+		}
+	}
+	// action names can contain spaces, like "PCIe PME, pciehp"
+	for _, action := range strings.Split(strings.Join(items, " "), ",") {
+		action = strings.TrimSpace(action)
+		if action == "" {
+			continue
+		}
+		desc.Actions = append(desc.Actions, action)
+	}
+	return desc
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+// findSourceForIRQ prefers the action directory in /proc/irq/N, falling back to the actions
+// and then to the chip reported in /proc/interrupts, which always give a valid (!= "") source.
+func (handler *Handler) findSourceForIRQ(irq int, desc Description) string {
 	irqDir := filepath.Join(handler.procfsRoot, "irq", fmt.Sprintf("%d", irq))
 	files, err := handler.fs.ReadDir(irqDir)
 	if err != nil {
 		handler.log.Printf("Error reading %q: %v", irqDir, err)
-		return "MISSING"
 	}
 	for _, file := range files {
 		if file.IsDir() {
 			return file.Name()
 		}
 	}
+	if len(desc.Actions) > 0 {
+		return strings.Join(desc.Actions, ",")
+	}
+	if desc.Chip != "" && desc.HWIRQ != "" {
+		return desc.Chip + "-" + desc.HWIRQ
+	}
+	if desc.Chip != "" {
+		return desc.Chip
+	}
+	if err != nil {
+		return "MISSING"
+	}
 	handler.log.Printf("Cannot find source for irq %d", irq)
 	return ""
 }
@@ -90,6 +90,50 @@ func TestReadStats(t *testing.T) {
 	}
 }
 
+func TestReadInterruptsDescriptions(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(rootDir) // clean up
+
+	procDir := filepath.Join(rootDir, "proc")
+	if err := os.Mkdir(procDir, 0755); err != nil {
+		t.Fatalf("Mkdir(%s) failed: %v", procDir, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(procDir, "interrupts"), []byte(fakeInterruptsDescriptions), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	ih := irqs.New(nullLog, procDir)
+	_, descs, err := ih.ReadInterrupts()
+	if err != nil {
+		t.Fatalf("ReadInterrupts(%s) failed: %v", procDir, err)
+	}
+
+	var descTestCases = []struct {
+		irqName  string
+		expected irqs.Description
+	}{
+		{"0", irqs.Description{Chip: "IR-IO-APIC", HWIRQ: "2", Trigger: "edge", Actions: []string{"timer"}}},
+		{"16", irqs.Description{Chip: "IR-IO-APIC", HWIRQ: "16", Trigger: "fasteoi", Actions: []string{"i801_smbus", "ehci_hcd:usb1"}}},
+		{"25", irqs.Description{Chip: "IR-PCI-MSI", HWIRQ: "458752", Trigger: "edge", Actions: []string{"PCIe PME", "pciehp"}}},
+		{"135", irqs.Description{Chip: "IR-PCI-MSI", HWIRQ: "514048", Trigger: "edge", Actions: []string{"snd_hda_intel:card1"}}},
+		{"136", irqs.Description{Chip: "dummy", HWIRQ: "44", Actions: []string{"rmi4_smbus"}}},
+		{"143", irqs.Description{Chip: "GICv3", HWIRQ: "27", Trigger: "Level", Actions: []string{"arch_timer"}}},
+		{"144", irqs.Description{Chip: "PCI-MSI-edge", Actions: []string{"eth0"}}},
+		{"LOC", irqs.Description{Label: "Local timer interrupts"}},
+	}
+	for _, tt := range descTestCases {
+		t.Run(fmt.Sprintf("irq %q", tt.irqName), func(t *testing.T) {
+			got := descs[tt.irqName]
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Description mismatch got %#v expected %#v", got, tt.expected)
+			}
+		})
+	}
+}
+
 func checkInterrupsDiff(src *bufio.Scanner, irqsDiffTestCases [4]int) error {
 	var irqsDiff [4]int
 	i := 0
@@ -194,6 +238,26 @@ func TestReportingStatsJSON(t *testing.T) {
 	}
 }
 
+func TestReportingStatsRates(t *testing.T) {
+	var buf bytes.Buffer
+	initTs := time.Now()
+	lastTime := initTs.Add(2 * time.Second)
+
+	reporter := irqs.NewReporterWithOptions(&buf, irqs.ReporterOptions{
+		Verbose:   2,
+		CPUs:      cpuset.New(2),
+		Label:     "SOFTIRQ",
+		Rates:     true,
+		StartTime: initTs,
+	})
+	reporter.Delta(lastTime, fakeStatsInit, fakeStatsLast)
+
+	expected := fmt.Sprintf("%v CPU=2 SOFTIRQ=8 +3 (1.50/s)\n", lastTime)
+	if got := buf.String(); got != expected {
+		t.Errorf("unexpected output: got=%q expected=%q", got, expected)
+	}
+}
+
 type irqAffinity struct {
 	IRQ         int
 	Source      string
@@ -219,6 +283,17 @@ func TestReadInfo(t *testing.T) {
 	if err := ioutil.WriteFile(filepath.Join(irqDir, "smp_affinity_list"), []byte("3,7"), 0644); err != nil {
 		t.Fatalf("WriteFile failed: %v", err)
 	}
+	// no action directory, the source must be taken from /proc/interrupts
+	irqDir = filepath.Join(procDir, "irq", "25")
+	if err := os.MkdirAll(irqDir, 0755); err != nil {
+		t.Fatalf("Mkdir(%s) failed: %v", irqDir, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(irqDir, "smp_affinity_list"), []byte("2"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(procDir, "interrupts"), []byte(fakeInterruptsDescriptions), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
 
 	flags := uint(0)
 
@@ -227,6 +302,9 @@ func TestReadInfo(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error parsing irqs from %q: %v", procDir, err)
 	}
+	if len(irqInfos) != 2 {
+		t.Fatalf("unexpected irqs: %v", irqInfos)
+	}
 
 	cpus := cpuset.New(0, 1, 2, 3, 4, 5, 6, 7)
 
@@ -247,6 +325,7 @@ func TestReadInfo(t *testing.T) {
 	}
 
 	var irqAffinityTestCases = []irqAffinity{
+		{25, "PCIe PME,pciehp", []int{2}},
 		{145, "xhci_hcd", []int{3, 7}},
 	}
 	for i, tt := range irqAffinityTestCases {
@@ -259,6 +338,122 @@ func TestReadInfo(t *testing.T) {
 	}
 }
 
+func TestReadInfoManaged(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(rootDir) // clean up
+
+	procDir := filepath.Join(rootDir, "proc")
+	sysDir := filepath.Join(rootDir, "sys")
+	debugDir := filepath.Join(sysDir, "kernel", "debug", "irq", "irqs")
+	if err := os.MkdirAll(debugDir, 0755); err != nil {
+		t.Fatalf("Mkdir(%s) failed: %v", debugDir, err)
+	}
+	for irq, attrs := range map[string]map[string]string{
+		"126": {
+			"smp_affinity_list":       "0-7",
+			"effective_affinity_list": "3",
+			"debug":                   fakeDebugFSManaged,
+		},
+		"131": {
+			"smp_affinity_list":       "0-7",
+			"effective_affinity_list": "0",
+			"debug":                   fakeDebugFSUnmanaged,
+		},
+		"140": {
+			"smp_affinity":            "ff\n",
+			"smp_affinity_list":       "0-7",
+			"effective_affinity_list": "2",
+		},
+	} {
+		irqDir := filepath.Join(procDir, "irq", irq)
+		if err := os.MkdirAll(irqDir, 0755); err != nil {
+			t.Fatalf("Mkdir(%s) failed: %v", irqDir, err)
+		}
+		for name, content := range attrs {
+			path := filepath.Join(irqDir, name)
+			if name == "debug" {
+				path = filepath.Join(debugDir, irq)
+			}
+			if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+		}
+	}
+
+	ih := irqs.NewWithSysFS(nullLog, procDir, sysDir)
+	irqInfos, err := ih.ReadInfo(irqs.EffectiveAffinity | irqs.DetectManaged)
+	if err != nil {
+		t.Fatalf("error parsing irqs from %q: %v", procDir, err)
+	}
+
+	got := make(map[int]irqs.Info)
+	for _, irqInfo := range irqInfos {
+		got[irqInfo.IRQ] = irqInfo
+	}
+
+	info := got[126]
+	if !info.CPUs.Equals(cpuset.New(3)) || !info.EffectiveCPUs.Equals(cpuset.New(3)) || !info.AffinityCPUs.Equals(cpuset.New(0, 1, 2, 3, 4, 5, 6, 7)) {
+		t.Errorf("unexpected affinity for irq 126: %+v", info)
+	}
+	if !info.Managed || !info.Unmovable || info.ManagedCheck != irqs.ManagedCheckDebugFS {
+		t.Errorf("irq 126 should be managed: %+v", info)
+	}
+	info = got[131]
+	if info.Managed || info.Unmovable || info.ManagedCheck != irqs.ManagedCheckDebugFS {
+		t.Errorf("irq 131 should not be managed: %+v", info)
+	}
+	info = got[140]
+	if info.Managed || info.ManagedCheck != "" {
+		t.Errorf("irq 140 should not be checked without probing: %+v", info)
+	}
+
+	irqInfos, err = ih.ReadInfo(irqs.EffectiveAffinity | irqs.DetectManaged | irqs.ProbeManaged)
+	if err != nil {
+		t.Fatalf("error parsing irqs from %q: %v", procDir, err)
+	}
+	for _, irqInfo := range irqInfos {
+		if irqInfo.IRQ == 140 && (irqInfo.Managed || irqInfo.Unmovable || irqInfo.ManagedCheck != irqs.ManagedCheckProbe) {
+			t.Errorf("irq 140 should be probed as movable: %+v", irqInfo)
+		}
+	}
+}
+
+const fakeDebugFSManaged string = `handler:  handle_edge_irq
+device:   0000:5e:00.0
+status:   0x00004000
+istate:   0x00000000
+ddepth:   0
+wdepth:   0
+dstate:   0x3740a200
+            IRQD_ACTIVATED
+            IRQD_IRQ_STARTED
+            IRQD_SINGLE_TARGET
+            IRQD_AFFINITY_SET
+            IRQD_AFFINITY_MANAGED
+            IRQD_MANAGED_SHUTDOWN
+node:     0
+affinity: 0-7
+effectiv: 3
+`
+
+const fakeDebugFSUnmanaged string = `handler:  handle_edge_irq
+device:   0000:3b:00.0
+status:   0x00004000
+istate:   0x00000000
+ddepth:   0
+wdepth:   0
+dstate:   0x3540a200
+            IRQD_ACTIVATED
+            IRQD_IRQ_STARTED
+            IRQD_SINGLE_TARGET
+node:     0
+affinity: 0-7
+effectiv: 0
+`
+
 const fakeInterrupts string = `            CPU0       CPU1       CPU2       CPU3       
    0:         13          0          0          0  IR-IO-APIC    2-edge      timer
    1:          0         21          0          0  IR-IO-APIC    1-edge      i8042
@@ -319,3 +514,17 @@ var fakeStatsLast irqs.Stats = map[int]irqs.Counter{
 	2: {"0": 0, "1": 0, "8": 4, "12": 0},
 	3: {"0": 0, "1": 0, "8": 0, "12": 717},
 }
+
+// fakeInterruptsDescriptions covers the formats of the IRQ descriptions: shared actions,
+// actions with spaces, no hwirq, no trigger and the chip-trigger names of the older kernels.
+const fakeInterruptsDescriptions string = `            CPU0       CPU1       CPU2       CPU3       
+   0:         13          0          0          0  IR-IO-APIC    2-edge      timer
+  16:          0          0        227          0  IR-IO-APIC   16-fasteoi   i801_smbus, ehci_hcd:usb1
+  25:          0          0          0          0  IR-PCI-MSI 458752-edge      PCIe PME, pciehp
+ 135:          0       1859          0          0  IR-PCI-MSI 514048-edge      snd_hda_intel:card1
+ 136:          0          0         21          0     dummy   44  rmi4_smbus
+ 143:          0          0          0          0     GICv3  27 Level     arch_timer
+ 144:          0          0          0          0  PCI-MSI-edge      eth0
+ 145:          0          0          0          0  IR-PCI-MSI 327680-edge      xhci_hcd
+ LOC:   14926901   16283403   14699417   15519974   Local timer interrupts
+`
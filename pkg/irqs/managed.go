@@ -0,0 +1,94 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqs
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// how Info.Managed and Info.Unmovable were detected
+const (
+	ManagedCheckDebugFS = "debugfs"
+	ManagedCheckProbe   = "probe"
+)
+
+// detectManaged tells if the IRQ affinity is managed by the kernel, and if it can be set from userspace.
+// We prefer the debugfs information, which is accurate but requires debugfs to be mounted. Otherwise,
+// if `probe` is set, we write back the current smp_affinity: the kernel rejects the write with EIO if
+// the affinity cannot be set from userspace. The probe is not harmless: the write sets the affinity
+// again, and the vector allocator may pick another effective cpu. It also cannot tell managed IRQs
+// from the other unmovable ones, like the per-CPU or the IRQF_NOBALANCING IRQs, so it only reports
+// the IRQ as unmovable, never as managed. Without debugfs and probe, the check is empty.
+func (handler *Handler) detectManaged(irq int, probe bool) (managed bool, unmovable bool, check string) {
+	managed, err := handler.readManagedFromDebugFS(irq)
+	if err == nil {
+		return managed, managed, ManagedCheckDebugFS
+	}
+	handler.log.Printf("Error reading debugfs info for irq %d: %v", irq, err)
+	if !probe {
+		return false, false, ""
+	}
+
+	unmovable, err = handler.probeUnmovable(irq)
+	if err == nil {
+		return false, unmovable, ManagedCheckProbe
+	}
+	handler.log.Printf("Error probing affinity for irq %d: %v", irq, err)
+	return false, false, ""
+}
+
+func (handler *Handler) readManagedFromDebugFS(irq int) (bool, error) {
+	if handler.sysfsRoot == "" {
+		return false, fmt.Errorf("unknown sysfs root")
+	}
+	// requires CONFIG_GENERIC_IRQ_DEBUGFS, see kernel/irq/debugfs.c
+	data, err := handler.fs.ReadFile(filepath.Join(handler.sysfsRoot, "kernel", "debug", "irq", "irqs", fmt.Sprintf("%d", irq)))
+	if err != nil {
+		return false, err
+	}
+	return ParseDebugFSManaged(string(data)), nil
+}
+
+// ParseDebugFSManaged tells if the content of /sys/kernel/debug/irq/irqs/N reports a managed IRQ
+func ParseDebugFSManaged(data string) bool {
+	for _, line := range strings.Split(data, "\n") {
+		if strings.TrimSpace(line) == "IRQD_AFFINITY_MANAGED" {
+			return true
+		}
+	}
+	return false
+}
+
+func (handler *Handler) probeUnmovable(irq int) (bool, error) {
+	path := filepath.Join(handler.procfsRoot, "irq", fmt.Sprintf("%d", irq), "smp_affinity")
+	data, err := handler.fs.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	err = handler.fs.WriteFile(path, data, 0644)
+	if err == nil {
+		return false, nil
+	}
+	if errors.Is(err, syscall.EIO) {
+		return true, nil
+	}
+	return false, err
+}
@@ -0,0 +1,166 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqs
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+)
+
+// Change is a change of the affinity of an IRQ. The affinities are in the cpulist format.
+type Change struct {
+	IRQ    int    `json:"irq"`
+	Source string `json:"source"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+func (ch Change) String() string {
+	return fmt.Sprintf("IRQ %3d [%24s]: %s -> %s", ch.IRQ, ch.Source, ch.From, ch.To)
+}
+
+// Revert returns the change which undoes this change
+func (ch Change) Revert() Change {
+	return Change{
+		IRQ:    ch.IRQ,
+		Source: ch.Source,
+		From:   ch.To,
+		To:     ch.From,
+	}
+}
+
+// Skipped is an IRQ which the plan could not move
+type Skipped struct {
+	IRQ    int    `json:"irq"`
+	Source string `json:"source"`
+	Reason string `json:"reason"`
+}
+
+// Plan computes the changes needed to move all the IRQs to the `housekeeping` cpus.
+// The IRQs already on the housekeeping cpus are left alone. If the requested affinity of an IRQ
+// includes some housekeeping cpus, we keep only those, otherwise we move the IRQ to all the
+// housekeeping cpus. The managed and the other unmovable IRQs (see DetectManaged) are skipped, because
+// they cannot be moved. The IRQs whose status is unknown are planned anyway, their change may fail.
+func Plan(infos []Info, housekeeping cpuset.CPUSet) ([]Change, []Skipped) {
+	var changes []Change
+	var skipped []Skipped
+	for _, info := range infos {
+		if info.AffinityCPUs.IsSubsetOf(housekeeping) {
+			continue
+		}
+		if info.Unmovable {
+			reason := "not settable"
+			if info.Managed {
+				reason = "managed"
+			}
+			skipped = append(skipped, Skipped{
+				IRQ:    info.IRQ,
+				Source: info.Source,
+				Reason: reason,
+			})
+			continue
+		}
+		target := info.AffinityCPUs.Intersection(housekeeping)
+		if target.Size() == 0 {
+			target = housekeeping
+		}
+		changes = append(changes, Change{
+			IRQ:    info.IRQ,
+			Source: info.Source,
+			From:   info.AffinityCPUs.String(),
+			To:     target.String(),
+		})
+	}
+	return changes, skipped
+}
+
+// SetAffinity writes the requested affinity of the IRQ
+func (handler *Handler) SetAffinity(irq int, cpus cpuset.CPUSet) error {
+	path := filepath.Join(handler.procfsRoot, "irq", fmt.Sprintf("%d", irq), "smp_affinity_list")
+	return handler.fs.WriteFile(path, []byte(cpus.String()+"\n"), 0644)
+}
+
+// Apply performs the change, checking first the current affinity is still the one the change expects.
+func (handler *Handler) Apply(ch Change) error {
+	from, err := cpuset.Parse(ch.From)
+	if err != nil {
+		return fmt.Errorf("error parsing %q: %v", ch.From, err)
+	}
+	to, err := cpuset.Parse(ch.To)
+	if err != nil {
+		return fmt.Errorf("error parsing %q: %v", ch.To, err)
+	}
+	data, err := handler.fs.ReadFile(filepath.Join(handler.procfsRoot, "irq", fmt.Sprintf("%d", ch.IRQ), "smp_affinity_list"))
+	if err != nil {
+		return err
+	}
+	cur, err := cpuset.Parse(strings.TrimSpace(string(data)))
+	if err != nil {
+		return fmt.Errorf("error parsing cpulist in %q: %v", data, err)
+	}
+	if !cur.Equals(from) {
+		return fmt.Errorf("irq %d affinity changed meantime: expected %v found %v", ch.IRQ, from, cur)
+	}
+	return handler.SetAffinity(ch.IRQ, to)
+}
+
+// JournalEntry records a change applied to the system, to be able to roll it back later.
+type JournalEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Change
+}
+
+// WriteJournalEntry appends the entry to the journal, in the JSON lines format.
+func WriteJournalEntry(w io.Writer, entry JournalEntry) error {
+	return json.NewEncoder(w).Encode(entry)
+}
+
+// ReadJournal returns all the entries of the journal, in the order they were written.
+func ReadJournal(r io.Reader) ([]JournalEntry, error) {
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return entries, fmt.Errorf("error parsing journal line %d: %v", lineNo, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Rollback returns the changes which undo all the journal entries, in the order they should be applied.
+func Rollback(entries []JournalEntry) []Change {
+	changes := make([]Change, 0, len(entries))
+	for idx := len(entries) - 1; idx >= 0; idx-- {
+		changes = append(changes, entries[idx].Change.Revert())
+	}
+	return changes
+}
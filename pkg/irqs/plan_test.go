@@ -0,0 +1,134 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqs_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
+)
+
+func TestPlan(t *testing.T) {
+	infos := []irqs.Info{
+		{IRQ: 0, Source: "timer", AffinityCPUs: cpuset.New(0, 1, 2, 3, 4, 5, 6, 7)},
+		{IRQ: 126, Source: "nvme0q3", AffinityCPUs: cpuset.New(4, 5), Managed: true, Unmovable: true, ManagedCheck: irqs.ManagedCheckDebugFS},
+		{IRQ: 130, Source: "pmu", AffinityCPUs: cpuset.New(5), Unmovable: true, ManagedCheck: irqs.ManagedCheckProbe},
+		{IRQ: 153, Source: "ens1f0-TxRx-1", AffinityCPUs: cpuset.New(4)},
+		{IRQ: 154, Source: "ens1f0-TxRx-2", AffinityCPUs: cpuset.New(1)},
+	}
+	changes, skipped := irqs.Plan(infos, cpuset.New(0, 1))
+
+	expectedChanges := []irqs.Change{
+		{IRQ: 0, Source: "timer", From: "0-7", To: "0-1"},
+		{IRQ: 153, Source: "ens1f0-TxRx-1", From: "4", To: "0-1"},
+	}
+	if !reflect.DeepEqual(changes, expectedChanges) {
+		t.Errorf("unexpected changes: got=%v expected=%v", changes, expectedChanges)
+	}
+	expectedSkipped := []irqs.Skipped{
+		{IRQ: 126, Source: "nvme0q3", Reason: "managed"},
+		{IRQ: 130, Source: "pmu", Reason: "not settable"},
+	}
+	if !reflect.DeepEqual(skipped, expectedSkipped) {
+		t.Errorf("unexpected skipped: got=%v expected=%v", skipped, expectedSkipped)
+	}
+}
+
+func TestApplyAndRollback(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(rootDir) // clean up
+
+	procDir := filepath.Join(rootDir, "proc")
+	for irq, cpulist := range map[string]string{
+		"0":   "0-7",
+		"153": "4",
+	} {
+		irqDir := filepath.Join(procDir, "irq", irq)
+		if err := os.MkdirAll(irqDir, 0755); err != nil {
+			t.Fatalf("Mkdir(%s) failed: %v", irqDir, err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(irqDir, "smp_affinity_list"), []byte(cpulist+"\n"), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	ih := irqs.New(nullLog, procDir)
+	irqInfos, err := ih.ReadInfo(0)
+	if err != nil {
+		t.Fatalf("error parsing irqs from %q: %v", procDir, err)
+	}
+	changes, _ := irqs.Plan(irqInfos, cpuset.New(0, 1))
+	if len(changes) != 2 {
+		t.Fatalf("unexpected changes: %v", changes)
+	}
+
+	var journal bytes.Buffer
+	for _, ch := range changes {
+		if err := ih.Apply(ch); err != nil {
+			t.Fatalf("Apply(%v) failed: %v", ch, err)
+		}
+		if err := irqs.WriteJournalEntry(&journal, irqs.JournalEntry{Timestamp: time.Now(), Change: ch}); err != nil {
+			t.Fatalf("WriteJournalEntry(%v) failed: %v", ch, err)
+		}
+	}
+	checkAffinity(t, procDir, "0", "0-1")
+	checkAffinity(t, procDir, "153", "0-1")
+
+	// the plan is now stale
+	if err := ih.Apply(changes[0]); err == nil {
+		t.Errorf("applying a stale change should fail")
+	}
+
+	entries, err := irqs.ReadJournal(&journal)
+	if err != nil {
+		t.Fatalf("ReadJournal failed: %v", err)
+	}
+	rollback := irqs.Rollback(entries)
+	if len(rollback) != 2 || rollback[0].IRQ != 153 || rollback[1].IRQ != 0 {
+		t.Fatalf("unexpected rollback: %v", rollback)
+	}
+	for _, ch := range rollback {
+		if err := ih.Apply(ch); err != nil {
+			t.Fatalf("Apply(%v) failed: %v", ch, err)
+		}
+	}
+	checkAffinity(t, procDir, "0", "0-7")
+	checkAffinity(t, procDir, "153", "4")
+}
+
+func checkAffinity(t *testing.T, procDir, irq, expected string) {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join(procDir, "irq", irq, "smp_affinity_list"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if got := strings.TrimSpace(string(data)); got != expected {
+		t.Errorf("unexpected affinity for irq %s: got=%q expected=%q", irq, got, expected)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	SampleKindIRQs     = "irqs"
+	SampleKindSoftirqs = "softirqs"
+)
+
+// Sample records the raw counters read at a given time, to replay a watch session later.
+type Sample struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Kind tells which counters were recorded, see the SampleKind* constants
+	Kind  string `json:"kind"`
+	Stats Stats  `json:"stats"`
+	// Sources are recorded only in the first sample, see the ReporterOptions
+	Sources map[string]string `json:"sources,omitempty"`
+}
+
+// WriteSample appends the sample to the recording, in the JSON lines format.
+func WriteSample(w io.Writer, sample Sample) error {
+	return json.NewEncoder(w).Encode(sample)
+}
+
+// ReadSamples returns all the samples of the recording, in the order they were written.
+// Lines can be huge on machines with many cpus, so we don't scan them.
+func ReadSamples(r io.Reader) ([]Sample, error) {
+	var samples []Sample
+	dec := json.NewDecoder(r)
+	for {
+		var sample Sample
+		err := dec.Decode(&sample)
+		if err == io.EOF {
+			return samples, nil
+		}
+		if err != nil {
+			return samples, fmt.Errorf("error parsing sample %d: %v", len(samples)+1, err)
+		}
+		samples = append(samples, sample)
+	}
+}
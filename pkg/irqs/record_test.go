@@ -0,0 +1,92 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqs_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
+)
+
+func TestRecordAndReadSamples(t *testing.T) {
+	initTs := time.Date(2026, time.October, 17, 10, 0, 0, 0, time.UTC)
+	samples := []irqs.Sample{
+		{
+			Timestamp: initTs,
+			Kind:      irqs.SampleKindIRQs,
+			Stats:     fakeStatsInit,
+			Sources:   map[string]string{"8": "rtc0"},
+		},
+		{
+			Timestamp: initTs.Add(time.Second),
+			Kind:      irqs.SampleKindIRQs,
+			Stats:     fakeStatsLast,
+		},
+	}
+
+	var buf bytes.Buffer
+	for _, sample := range samples {
+		if err := irqs.WriteSample(&buf, sample); err != nil {
+			t.Fatalf("WriteSample failed: %v", err)
+		}
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != len(samples) {
+		t.Errorf("expected one line per sample, got %d lines", lines)
+	}
+
+	got, err := irqs.ReadSamples(&buf)
+	if err != nil {
+		t.Fatalf("ReadSamples failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, samples) {
+		t.Errorf("samples mismatch\ngot=%+v\nexpected=%+v", got, samples)
+	}
+
+	_, err = irqs.ReadSamples(strings.NewReader("{\"kind\":\"irqs\"}\n{broken"))
+	if err == nil {
+		t.Errorf("expected error reading a broken recording")
+	}
+}
+
+func TestReportingRecordedTime(t *testing.T) {
+	initTs := time.Date(2026, time.October, 17, 10, 0, 0, 0, time.UTC)
+	lastTs := initTs.Add(2 * time.Second)
+
+	var buf bytes.Buffer
+	reporter := irqs.NewReporterWithOptions(&buf, irqs.ReporterOptions{
+		Verbose:   1,
+		CPUs:      cpuset.New(2),
+		Rates:     true,
+		StartTime: initTs,
+		Now: func() time.Time {
+			return lastTs
+		},
+	})
+	reporter.Summary(initTs, fakeStatsInit, fakeStatsLast)
+
+	expected := fmt.Sprintf("\nIRQ summary on cpus 2 after %v\nCPU=2 IRQ=8 +3 (1.50/s)\n", 2*time.Second)
+	if got := buf.String(); got != expected {
+		t.Errorf("unexpected output: got=%q expected=%q", got, expected)
+	}
+}
@@ -27,71 +27,145 @@ import (
 
 type Reporter interface {
 	Delta(ts time.Time, prevStats, lastStats Stats)
-	Summary(initTs time.Time, prevStats, lastStats Stats)
+	// Summary reports the counters over the whole watch, and returns ErrRulesViolated if the counters break the rules
+	Summary(initTs time.Time, prevStats, lastStats Stats) error
+}
+
+type ReporterOptions struct {
+	JSONOutput bool
+	Verbose    int
+	CPUs       cpuset.CPUSet
+	// Label names the counters in the text output. Default is "IRQ"
+	Label string
+	// Rates enables the per-second rates. The rate of the first delta is computed from StartTime.
+	Rates     bool
+	StartTime time.Time
+	// Rules are evaluated in the summary, if not empty
+	Rules Rules
+	// GroupBy aggregates the counters, see the GroupBy* constants
+	GroupBy string
+	// Top limits the reports to the first Top counters by count. 0 means all.
+	Top int
+	// Sources maps the counter names to the device and action names reported next to them
+	Sources map[string]string
+	// Now tells the time the summary is computed at. Default is time.Now, replays use the recorded time.
+	Now func() time.Time
 }
 
 func NewReporter(sink io.Writer, jsonOutput bool, verbose int, cpus cpuset.CPUSet) Reporter {
-	if jsonOutput {
+	return NewReporterWithOptions(sink, ReporterOptions{
+		JSONOutput: jsonOutput,
+		Verbose:    verbose,
+		CPUs:       cpus,
+	})
+}
+
+func NewReporterWithOptions(sink io.Writer, opts ReporterOptions) Reporter {
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+	if opts.JSONOutput {
 		return &reporterJSON{
-			verbose: verbose,
-			cpus:    cpus,
+			verbose: opts.Verbose,
+			cpus:    opts.CPUs,
 			sink:    sink,
+			rates:   opts.Rates,
+			lastTs:  opts.StartTime,
+			rules:   opts.Rules,
+			groupBy: opts.GroupBy,
+			top:     opts.Top,
+			sources: opts.Sources,
+			now:     now,
 		}
 	}
+	label := opts.Label
+	if label == "" {
+		label = "IRQ"
+	}
 	return &reporterText{
-		verbose: verbose,
-		cpus:    cpus,
+		verbose: opts.Verbose,
+		cpus:    opts.CPUs,
 		sink:    sink,
+		label:   label,
+		rates:   opts.Rates,
+		lastTs:  opts.StartTime,
+		rules:   opts.Rules,
+		groupBy: opts.GroupBy,
+		top:     opts.Top,
+		sources: opts.Sources,
+		now:     now,
 	}
-
 }
 
 type reporterText struct {
 	verbose int
 	cpus    cpuset.CPUSet
 	sink    io.Writer
+	label   string
+	rates   bool
+	lastTs  time.Time
+	rules   Rules
+	groupBy string
+	top     int
+	sources map[string]string
+	now     func() time.Time
 }
 
 func (rt *reporterText) Delta(ts time.Time, prevStats, lastStats Stats) {
+	interval := ts.Sub(rt.lastTs)
+	rt.lastTs = ts
 	if rt.verbose < 2 {
 		return
 	}
-	delta := prevStats.Delta(lastStats)
-	cpuids := rt.cpus.List()
-	for _, cpuid := range cpuids {
-		counter, ok := delta[cpuid]
-		if !ok {
-			continue
-		}
-		for irqName, val := range counter {
-			if val == 0 {
-				continue
-			}
-			fmt.Fprintf(rt.sink, "%v CPU=%d IRQ=%s +%d\n", ts, cpuid, irqName, val)
-		}
+	for _, entry := range Breakdown(rt.cpus, prevStats.Delta(lastStats), rt.groupBy, rt.top, rt.sources, interval) {
+		fmt.Fprintf(rt.sink, "%v %s\n", ts, rt.formatEntry(entry))
 	}
 }
 
-func (rt *reporterText) Summary(initTs time.Time, prevStats, lastStats Stats) {
-	if rt.verbose < 1 {
-		return
+func (rt *reporterText) formatEntry(entry BreakdownEntry) string {
+	var res string
+	switch rt.groupBy {
+	case GroupByCPU:
+		res = fmt.Sprintf("CPU=%d total=+%d", entry.CPUs[0], entry.Count)
+	case GroupByIRQ:
+		res = fmt.Sprintf("%s=%s +%d on cpus %v", rt.label, entry.Name, entry.Count, entry.CPUs)
+	default:
+		res = fmt.Sprintf("CPU=%d %s=%s +%d", entry.CPUs[0], rt.label, entry.Name, entry.Count)
+	}
+	if rt.rates {
+		res += fmt.Sprintf(" (%.2f/s)", entry.Rate)
+	}
+	if entry.Source != "" {
+		res += fmt.Sprintf(" [%s]", entry.Source)
 	}
-	timeDelta := time.Now().Sub(initTs)
+	return res
+}
+
+func (rt *reporterText) Summary(initTs time.Time, prevStats, lastStats Stats) error {
+	timeDelta := rt.now().Sub(initTs)
 	delta := prevStats.Delta(lastStats)
-	cpuids := rt.cpus.List()
+	if rt.verbose >= 1 {
+		rt.printSummary(timeDelta, delta)
+	}
+	if rt.rules.IsEmpty() {
+		return nil
+	}
+	verdict := rt.rules.Evaluate(rt.cpus, delta, timeDelta)
+	fmt.Fprintf(rt.sink, "\nverdict: %s\n", verdict)
+	for _, vi := range verdict.Violations {
+		fmt.Fprintf(rt.sink, "violation: %s\n", vi)
+	}
+	if !verdict.Pass {
+		return ErrRulesViolated
+	}
+	return nil
+}
 
-	fmt.Fprintf(rt.sink, "\nIRQ summary on cpus %v after %v\n", rt.cpus, timeDelta)
-	for _, cpuid := range cpuids {
-		counter, ok := delta[cpuid]
-		if !ok {
-			continue
-		}
-		for irqName, val := range counter {
-			if val == 0 {
-				continue
-			}
-			fmt.Fprintf(rt.sink, "CPU=%d IRQ=%s +%d\n", cpuid, irqName, val)
-		}
+func (rt *reporterText) printSummary(timeDelta time.Duration, delta Stats) {
+	fmt.Fprintf(rt.sink, "\n%s summary on cpus %v after %v\n", rt.label, rt.cpus, timeDelta)
+	for _, entry := range Breakdown(rt.cpus, delta, rt.groupBy, rt.top, rt.sources, timeDelta) {
+		fmt.Fprintln(rt.sink, rt.formatEntry(entry))
 	}
 }
 
@@ -99,14 +173,29 @@ type reporterJSON struct {
 	verbose int
 	cpus    cpuset.CPUSet
 	sink    io.Writer
+	rates   bool
+	lastTs  time.Time
+	rules   Rules
+	groupBy string
+	top     int
+	sources map[string]string
+	now     func() time.Time
 }
 
+// CPUid -> counter name -> events per second
+type Rates map[int]map[string]float64
+
 type irqDelta struct {
 	Timestamp time.Time `json:"timestamp"`
 	Counters  Stats     `json:"counters"`
+	Rates     Rates     `json:"rates,omitempty"`
+	// Breakdown is reported only if grouping or top counters are requested
+	Breakdown []BreakdownEntry `json:"breakdown,omitempty"`
 }
 
 func (rj *reporterJSON) Delta(ts time.Time, prevStats, lastStats Stats) {
+	interval := ts.Sub(rj.lastTs)
+	rj.lastTs = ts
 	if rj.verbose < 2 {
 		return
 	}
@@ -114,6 +203,10 @@ func (rj *reporterJSON) Delta(ts time.Time, prevStats, lastStats Stats) {
 		Timestamp: ts,
 		Counters:  countersForCPUs(rj.cpus, prevStats.Delta(lastStats)),
 	}
+	if rj.rates {
+		res.Rates = ratesFor(res.Counters, interval)
+	}
+	res.Breakdown = rj.breakdown(prevStats.Delta(lastStats), interval)
 	json.NewEncoder(rj.sink).Encode(res)
 }
 
@@ -124,19 +217,64 @@ type irqwatchDuration struct {
 type irqSummary struct {
 	Elapsed  irqwatchDuration `json:"elapsed"`
 	Counters Stats            `json:"counters"`
+	Rates    Rates            `json:"rates,omitempty"`
+	Verdict  *Verdict         `json:"verdict,omitempty"`
+	// Breakdown is reported only if grouping or top counters are requested
+	Breakdown []BreakdownEntry `json:"breakdown,omitempty"`
 }
 
-func (rj *reporterJSON) Summary(initTs time.Time, prevStats, lastStats Stats) {
-	if rj.verbose < 1 {
-		return
+func (rj *reporterJSON) Summary(initTs time.Time, prevStats, lastStats Stats) error {
+	timeDelta := rj.now().Sub(initTs)
+	delta := prevStats.Delta(lastStats)
+	var verdict *Verdict
+	if !rj.rules.IsEmpty() {
+		res := rj.rules.Evaluate(rj.cpus, delta, timeDelta)
+		verdict = &res
+	}
+	if rj.verbose >= 1 || verdict != nil {
+		res := irqSummary{
+			Elapsed: irqwatchDuration{
+				d: timeDelta,
+			},
+			Counters:  countersForCPUs(rj.cpus, delta),
+			Verdict:   verdict,
+			Breakdown: rj.breakdown(delta, timeDelta),
+		}
+		if rj.rates {
+			res.Rates = ratesFor(res.Counters, timeDelta)
+		}
+		json.NewEncoder(rj.sink).Encode(res)
 	}
-	res := irqSummary{
-		Elapsed: irqwatchDuration{
-			d: time.Now().Sub(initTs),
-		},
-		Counters: countersForCPUs(rj.cpus, prevStats.Delta(lastStats)),
+	if verdict != nil && !verdict.Pass {
+		return ErrRulesViolated
 	}
-	json.NewEncoder(rj.sink).Encode(res)
+	return nil
+}
+
+func (rj *reporterJSON) breakdown(delta Stats, interval time.Duration) []BreakdownEntry {
+	if rj.groupBy == GroupByNone && rj.top <= 0 {
+		return nil
+	}
+	return Breakdown(rj.cpus, delta, rj.groupBy, rj.top, rj.sources, interval)
+}
+
+func ratesFor(stats Stats, interval time.Duration) Rates {
+	res := make(Rates)
+	for cpuid, counter := range stats {
+		cpuRates := make(map[string]float64)
+		for name, val := range counter {
+			cpuRates[name] = rate(val, interval)
+		}
+		res[cpuid] = cpuRates
+	}
+	return res
+}
+
+func rate(val uint64, interval time.Duration) float64 {
+	if interval <= 0 {
+		return 0
+	}
+	return float64(val) / interval.Seconds()
 }
 
 func countersForCPUs(cpus cpuset.CPUSet, stats Stats) Stats {
@@ -0,0 +1,174 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqs
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+)
+
+var ErrRulesViolated = errors.New("irq rules violated")
+
+const (
+	RuleMaxPerCPU = "max-per-cpu"
+	RuleRateLimit = "rate-limit"
+	RuleAllowed   = "allowed"
+)
+
+// Rules are the acceptance criteria evaluated on the counters of the watched cpus
+type Rules struct {
+	// MaxPerCPU is the maximum amount of interrupts, of any kind, each cpu can serve. 0 means no limit.
+	MaxPerCPU uint64
+	// RateLimits maps counter names to the maximum events per second each cpu can serve
+	RateLimits map[string]float64
+	// Allowed are the only counter names each cpu can serve. Empty means any.
+	Allowed []string
+}
+
+func (rules Rules) IsEmpty() bool {
+	return rules.MaxPerCPU == 0 && len(rules.RateLimits) == 0 && len(rules.Allowed) == 0
+}
+
+// ParseRateLimit parses rate limits like "LOC<=1000/s". The "/s" suffix is optional.
+func ParseRateLimit(s string) (string, float64, error) {
+	items := strings.SplitN(s, "<=", 2)
+	if len(items) != 2 {
+		return "", 0, fmt.Errorf("malformed rate limit %q, expected NAME<=VALUE/s", s)
+	}
+	name := strings.TrimSpace(items[0])
+	if name == "" {
+		return "", 0, fmt.Errorf("malformed rate limit %q: missing name", s)
+	}
+	val, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(items[1]), "/s"), 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("malformed rate limit %q: %v", s, err)
+	}
+	return name, val, nil
+}
+
+type Violation struct {
+	CPU   int     `json:"cpu"`
+	Rule  string  `json:"rule"`
+	Name  string  `json:"name,omitempty"`
+	Value float64 `json:"value"`
+	Limit float64 `json:"limit,omitempty"`
+}
+
+func (vi Violation) String() string {
+	switch vi.Rule {
+	case RuleMaxPerCPU:
+		return fmt.Sprintf("CPU=%d served %d interrupts, limit is %d", vi.CPU, uint64(vi.Value), uint64(vi.Limit))
+	case RuleRateLimit:
+		return fmt.Sprintf("CPU=%d %s rate is %.2f/s, limit is %.2f/s", vi.CPU, vi.Name, vi.Value, vi.Limit)
+	case RuleAllowed:
+		return fmt.Sprintf("CPU=%d served %d %s, which is not allowed", vi.CPU, uint64(vi.Value), vi.Name)
+	}
+	return fmt.Sprintf("CPU=%d %s %s value=%v limit=%v", vi.CPU, vi.Rule, vi.Name, vi.Value, vi.Limit)
+}
+
+type Verdict struct {
+	Pass       bool        `json:"pass"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+func (ve Verdict) String() string {
+	if ve.Pass {
+		return "PASS"
+	}
+	return "FAIL"
+}
+
+// Evaluate checks the `delta` counters of the `cpus` over the `elapsed` time against the rules.
+// The violations are sorted by cpu, then by rule, then by name.
+func (rules Rules) Evaluate(cpus cpuset.CPUSet, delta Stats, elapsed time.Duration) Verdict {
+	allowed := make(map[string]bool)
+	for _, name := range rules.Allowed {
+		allowed[name] = true
+	}
+
+	var violations []Violation
+	for _, cpuid := range cpus.List() {
+		counter := delta[cpuid]
+
+		var total uint64
+		for _, name := range sortedNames(counter) {
+			val := counter[name]
+			if val == 0 {
+				continue
+			}
+			total += val
+			if len(allowed) > 0 && !allowed[name] {
+				violations = append(violations, Violation{
+					CPU:   cpuid,
+					Rule:  RuleAllowed,
+					Name:  name,
+					Value: float64(val),
+				})
+			}
+			limit, ok := rules.RateLimits[name]
+			if !ok {
+				continue
+			}
+			if r := rate(val, elapsed); r > limit {
+				violations = append(violations, Violation{
+					CPU:   cpuid,
+					Rule:  RuleRateLimit,
+					Name:  name,
+					Value: r,
+					Limit: limit,
+				})
+			}
+		}
+		if rules.MaxPerCPU > 0 && total > rules.MaxPerCPU {
+			violations = append(violations, Violation{
+				CPU:   cpuid,
+				Rule:  RuleMaxPerCPU,
+				Value: float64(total),
+				Limit: float64(rules.MaxPerCPU),
+			})
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].CPU != violations[j].CPU {
+			return violations[i].CPU < violations[j].CPU
+		}
+		if violations[i].Rule != violations[j].Rule {
+			return violations[i].Rule < violations[j].Rule
+		}
+		return violations[i].Name < violations[j].Name
+	})
+	return Verdict{
+		Pass:       len(violations) == 0,
+		Violations: violations,
+	}
+}
+
+func sortedNames(counter Counter) []string {
+	var names []string
+	for name := range counter {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,116 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package irqs_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	var testCases = []struct {
+		value    string
+		name     string
+		limit    float64
+		expected bool
+	}{
+		{"LOC<=1000/s", "LOC", 1000, true},
+		{"RES <= 2.5", "RES", 2.5, true},
+		{"LOC=1000/s", "", 0, false},
+		{"<=1000/s", "", 0, false},
+		{"LOC<=fast", "", 0, false},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.value, func(t *testing.T) {
+			name, limit, err := irqs.ParseRateLimit(tt.value)
+			if (err == nil) != tt.expected {
+				t.Fatalf("unexpected error status: %v", err)
+			}
+			if name != tt.name || limit != tt.limit {
+				t.Errorf("got %q %v expected %q %v", name, limit, tt.name, tt.limit)
+			}
+		})
+	}
+}
+
+func TestEvaluateRules(t *testing.T) {
+	delta := irqs.Stats{
+		2: irqs.Counter{"LOC": 2500, "RES": 4, "CAL": 0},
+		3: irqs.Counter{"LOC": 1500},
+		4: irqs.Counter{"LOC": 9000, "153": 10},
+	}
+	rules := irqs.Rules{
+		MaxPerCPU:  2000,
+		RateLimits: map[string]float64{"LOC": 1000},
+		Allowed:    []string{"LOC", "CAL"},
+	}
+
+	verdict := rules.Evaluate(cpuset.New(2, 3), delta, 2*time.Second)
+	expected := irqs.Verdict{
+		Pass: false,
+		Violations: []irqs.Violation{
+			{CPU: 2, Rule: irqs.RuleAllowed, Name: "RES", Value: 4},
+			{CPU: 2, Rule: irqs.RuleMaxPerCPU, Value: 2504, Limit: 2000},
+			{CPU: 2, Rule: irqs.RuleRateLimit, Name: "LOC", Value: 1250, Limit: 1000},
+		},
+	}
+	if !reflect.DeepEqual(verdict, expected) {
+		t.Errorf("unexpected verdict\ngot=%+v\nexpected=%+v", verdict, expected)
+	}
+
+	verdict = rules.Evaluate(cpuset.New(3), delta, 2*time.Second)
+	if !verdict.Pass || len(verdict.Violations) != 0 {
+		t.Errorf("unexpected verdict: %+v", verdict)
+	}
+}
+
+func TestReportingRulesJSON(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := irqs.NewReporterWithOptions(&buf, irqs.ReporterOptions{
+		JSONOutput: true,
+		CPUs:       cpuset.New(2),
+		Rules: irqs.Rules{
+			Allowed: []string{"LOC"},
+		},
+	})
+	err := reporter.Summary(time.Now(), fakeStatsInit, fakeStatsLast)
+	if err != irqs.ErrRulesViolated {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var res struct {
+		Verdict irqs.Verdict `json:"verdict"`
+	}
+	if err := json.NewDecoder(&buf).Decode(&res); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if res.Verdict.Pass || len(res.Verdict.Violations) == 0 {
+		t.Errorf("unexpected verdict: %+v", res.Verdict)
+	}
+	for _, vi := range res.Verdict.Violations {
+		if vi.CPU != 2 || vi.Rule != irqs.RuleAllowed || vi.Name == "LOC" {
+			t.Errorf("unexpected violation: %+v", vi)
+		}
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"strings"
 
 	"github.com/openshift-kni/debug-tools/pkg/fswrap"
+	"github.com/openshift-kni/debug-tools/pkg/irqs"
 )
 
 // presented in kernel order
@@ -36,10 +37,29 @@ func Names() []string {
 
 type Info struct {
 	CPUs int
-	// softirq -> percpu count
+	// column -> cpuid. Offline CPUs are not reported, so columns and cpuids may differ.
+	CPUIDs []int
+	// softirq -> per column count
 	Counters map[string][]uint64
 }
 
+// Stats returns the counters in the same format irqs.Handler.ReadStats uses, keyed by cpuid.
+func (info *Info) Stats() irqs.Stats {
+	stats := make(irqs.Stats)
+	for _, cpuid := range info.CPUIDs {
+		stats[cpuid] = make(irqs.Counter)
+	}
+	for name, vals := range info.Counters {
+		for colIdx, val := range vals {
+			if colIdx >= len(info.CPUIDs) {
+				break
+			}
+			stats[info.CPUIDs[colIdx]][name] = val
+		}
+	}
+	return stats
+}
+
 type Handler struct {
 	log        *log.Logger
 	procfsRoot string
@@ -63,17 +83,39 @@ func (handler *Handler) ReadInfo() (*Info, error) {
 	return parseSoftirqs(handler.log, src)
 }
 
+func (handler *Handler) ReadStats() (irqs.Stats, error) {
+	info, err := handler.ReadInfo()
+	if err != nil {
+		return nil, err
+	}
+	return info.Stats(), nil
+}
+
 func parseSoftirqs(logger *log.Logger, rd io.Reader) (*Info, error) {
 	src := bufio.NewScanner(rd)
 	src.Scan()
 	cpus := strings.Fields(src.Text())
 	ret := Info{
 		CPUs:     len(cpus),
+		CPUIDs:   make([]int, 0, len(cpus)),
 		Counters: make(map[string][]uint64),
 	}
+	// the first line is like "                    CPU0       CPU1       CPU2       CPU3"
+	// same as /proc/interrupts, we should never assume columnid == cpuid.
+	for _, cpu := range cpus {
+		var cpuid int
+		n, err := fmt.Sscanf(cpu, "CPU%d", &cpuid)
+		if n != 1 || err != nil {
+			return nil, fmt.Errorf("cannot parse cpu name %q: err=%v", cpu, err)
+		}
+		ret.CPUIDs = append(ret.CPUIDs, cpuid)
+	}
 
 	for src.Scan() {
 		items := strings.Fields(src.Text())
+		if len(items) == 0 {
+			continue
+		}
 		var vals []uint64
 		for _, item := range items[1:] {
 			v, err := strconv.ParseUint(item, 10, 64)
@@ -84,6 +84,63 @@ func TestReadInfo(t *testing.T) {
 	}
 }
 
+func TestReadStatsOfflineCPUs(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(rootDir) // clean up
+
+	procDir := filepath.Join(rootDir, "proc")
+	if err := os.Mkdir(procDir, 0755); err != nil {
+		t.Fatalf("Mkdir(%s) failed: %v", procDir, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(procDir, "softirqs"), []byte(fakeSoftirqsOffline), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	ih := softirqs.New(nullLog, procDir)
+	stats, err := ih.ReadStats()
+	if err != nil {
+		t.Fatalf("ReadStats(%s) failed: %v", procDir, err)
+	}
+	if _, ok := stats[1]; ok {
+		t.Errorf("unexpected stats for offline cpu 1: %v", stats[1])
+	}
+
+	var softirqTestCases = []struct {
+		cpuID       int
+		softirqName string
+		value       uint64
+	}{
+		{0, "TIMER", 128764},
+		{2, "TIMER", 129415},
+		{3, "TIMER", 129834},
+		{3, "NET_RX", 19},
+	}
+	for _, tt := range softirqTestCases {
+		t.Run(fmt.Sprintf("cpu %d softirq %q", tt.cpuID, tt.softirqName), func(t *testing.T) {
+			v := stats[tt.cpuID][tt.softirqName]
+			if v != tt.value {
+				t.Errorf("Counters mismatch got %v expected %v", v, tt.value)
+			}
+		})
+	}
+}
+
+// CPU1 is offline
+const fakeSoftirqsOffline string = `                    CPU0       CPU2       CPU3       
+          HI:       3853      75886       3513
+       TIMER:     128764     129415     129834
+      NET_TX:         10       1282          3
+      NET_RX:     162388          9         19
+       BLOCK:       1083       1319        802
+    IRQ_POLL:          0          0          0
+     TASKLET:        626      33417         11
+       SCHED:     424448     406115     349634
+     HRTIMER:          0          0          0
+         RCU:     258340     252164     250633`
+
 const fakeSoftirqs string = `                    CPU0       CPU1       CPU2       CPU3       
           HI:       3853     390251      75886       3513
        TIMER:     128764     200838     129415     129834
@@ -0,0 +1,183 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package isolation
+
+import (
+	"fmt"
+	"sort"
+
+	cpuset "k8s.io/utils/cpuset"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+	CPUs     []int    `json:"cpus,omitempty"`
+}
+
+func (fi Finding) String() string {
+	if len(fi.CPUs) == 0 {
+		return fmt.Sprintf("[%-7s] %-28s %s", fi.Severity, fi.Code, fi.Message)
+	}
+	return fmt.Sprintf("[%-7s] %-28s %s (cpus: %s)", fi.Severity, fi.Code, fi.Message, cpuset.New(fi.CPUs...).String())
+}
+
+// Findings collects the findings of a check
+type Findings []Finding
+
+// Add appends a finding, whose message is built from `format` and `args` like fmt.Sprintf does.
+// `cpus` can be empty if the finding is not about specific cpus.
+func (fs *Findings) Add(sev Severity, code string, cpus cpuset.CPUSet, format string, args ...interface{}) {
+	fi := Finding{
+		Severity: sev,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if cpus.Size() > 0 {
+		fi.CPUs = cpus.List()
+	}
+	*fs = append(*fs, fi)
+}
+
+// Check cross-checks the boot parameters, the kernel runtime view and the CPUs
+// the caller expects to be isolated, and reports every inconsistency found.
+// If `cpus` covers all the present CPUs, it is assumed the caller did not
+// provide an explicit isolated set, and the related checks are skipped.
+func Check(info Info, cpus cpuset.CPUSet) []Finding {
+	var ck Findings
+	cl := info.CmdLine
+	none := cpuset.New()
+
+	keys := make([]string, 0, len(cl.Errors))
+	for key := range cl.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		ck.Add(SeverityError, "cmdline-parse-error", none, "cannot parse %q: %s", key, cl.Errors[key])
+	}
+
+	if cl.IsolCPUs != nil && info.SysFS.Isolated != nil && !cl.IsolCPUs.Equals(*info.SysFS.Isolated) {
+		ck.Add(SeverityError, "isolcpus-mismatch", symmetricDifference(*cl.IsolCPUs, *info.SysFS.Isolated),
+			"isolcpus=%s on the command line but the kernel reports isolated=%s", cl.IsolCPUs.String(), info.SysFS.Isolated.String())
+	}
+	if cl.NohzFull != nil && info.SysFS.NohzFull != nil && !cl.NohzFull.Equals(*info.SysFS.NohzFull) {
+		ck.Add(SeverityError, "nohz_full-mismatch", symmetricDifference(*cl.NohzFull, *info.SysFS.NohzFull),
+			"nohz_full=%s on the command line but the kernel reports nohz_full=%s", cl.NohzFull.String(), info.SysFS.NohzFull.String())
+	}
+
+	isolated := info.Isolated()
+	nohzFull := info.NohzFull()
+
+	if cl.IsolCPUs != nil && cl.NohzFull != nil && !cl.IsolCPUs.Equals(*cl.NohzFull) {
+		ck.Add(SeverityWarning, "isolcpus-nohz_full-differ", symmetricDifference(*cl.IsolCPUs, *cl.NohzFull),
+			"isolcpus and nohz_full cover different CPUs")
+	}
+	if cl.IsolCPUs != nil && !cl.HasIsolCPUsFlag(IsolCPUsFlagManagedIRQ) {
+		ck.Add(SeverityInfo, "isolcpus-no-managed_irq", none,
+			"isolcpus lacks the %q flag, managed IRQs can still be routed to isolated CPUs", IsolCPUsFlagManagedIRQ)
+	}
+	if cl.RCUNoCBs != nil && !nohzFull.IsSubsetOf(*cl.RCUNoCBs) {
+		ck.Add(SeverityWarning, "rcu_nocbs-incomplete", nohzFull.Difference(*cl.RCUNoCBs),
+			"nohz_full CPUs not covered by rcu_nocbs=%s", cl.RCUNoCBs.String())
+	}
+	if cl.IRQAffinity != nil {
+		if overlap := cl.IRQAffinity.Intersection(isolated.Union(nohzFull)); overlap.Size() > 0 {
+			ck.Add(SeverityError, "irqaffinity-overlap", overlap,
+				"irqaffinity=%s includes isolated CPUs", cl.IRQAffinity.String())
+		}
+	} else if isolated.Size() > 0 || nohzFull.Size() > 0 {
+		ck.Add(SeverityWarning, "irqaffinity-missing", none,
+			"irqaffinity is not set, the default IRQ affinity can include isolated CPUs")
+	}
+	if nohzFull.Size() > 0 && cl.SkewTick != "1" {
+		ck.Add(SeverityWarning, "skew_tick-missing", none,
+			"nohz_full is set but skew_tick=1 is not")
+	}
+	if cl.Idle != "" {
+		ck.Add(SeverityInfo, "idle", none, "idle=%s", cl.Idle)
+	}
+	if cl.IntelPState != "" {
+		ck.Add(SeverityInfo, "intel_pstate", none, "intel_pstate=%s", cl.IntelPState)
+	}
+
+	online := info.Online()
+	if online.Size() > 0 {
+		if housekeeping := online.Difference(isolated.Union(nohzFull)); housekeeping.Size() == 0 && (isolated.Size() > 0 || nohzFull.Size() > 0) {
+			ck.Add(SeverityError, "no-housekeeping-cpus", none, "all the online CPUs are isolated")
+		}
+		if info.SysFS.Offline != nil {
+			if offIsol := info.SysFS.Offline.Intersection(isolated.Union(nohzFull)); offIsol.Size() > 0 {
+				ck.Add(SeverityWarning, "isolated-offline", offIsol, "isolated CPUs are offline")
+			}
+		}
+	}
+
+	checkCPUs(&ck, info, cpus, isolated, nohzFull)
+	return ck
+}
+
+func checkCPUs(ck *Findings, info Info, cpus, isolated, nohzFull cpuset.CPUSet) {
+	none := cpuset.New()
+	if info.SysFS.Present == nil {
+		ck.Add(SeverityInfo, "present-unknown", none, "cannot detect the present CPUs, skipping the cpulist checks")
+		return
+	}
+	if notPresent := cpus.Difference(*info.SysFS.Present); notPresent.Size() > 0 && !info.SysFS.Present.IsSubsetOf(cpus) {
+		ck.Add(SeverityError, "cpulist-not-present", notPresent, "CPUs in the checked cpulist are not present")
+	}
+	cpus = cpus.Intersection(*info.SysFS.Present)
+	if info.SysFS.Present.IsSubsetOf(cpus) {
+		ck.Add(SeverityInfo, "cpulist-unset", none, "the checked cpulist covers all the present CPUs, skipping the cpulist checks")
+		return
+	}
+	if cpus.Size() == 0 {
+		return
+	}
+	if info.SysFS.Offline != nil {
+		if offline := cpus.Intersection(*info.SysFS.Offline); offline.Size() > 0 {
+			ck.Add(SeverityError, "cpulist-offline", offline, "CPUs in the checked cpulist are offline")
+		}
+	}
+	if notIsolated := cpus.Difference(isolated); notIsolated.Size() > 0 {
+		ck.Add(SeverityError, "cpulist-not-isolated", notIsolated, "CPUs in the checked cpulist are not isolated")
+	}
+	if notNohz := cpus.Difference(nohzFull); notNohz.Size() > 0 {
+		ck.Add(SeverityWarning, "cpulist-not-nohz_full", notNohz, "CPUs in the checked cpulist are not nohz_full")
+	}
+	if cl := info.CmdLine; cl.RCUNoCBs != nil {
+		if notNoCBs := cpus.Difference(*cl.RCUNoCBs); notNoCBs.Size() > 0 {
+			ck.Add(SeverityWarning, "cpulist-not-rcu_nocbs", notNoCBs, "CPUs in the checked cpulist do not offload RCU callbacks")
+		}
+	}
+	if extra := isolated.Difference(cpus); extra.Size() > 0 {
+		ck.Add(SeverityInfo, "isolated-not-in-cpulist", extra, "isolated CPUs not in the checked cpulist")
+	}
+}
+
+func symmetricDifference(a, b cpuset.CPUSet) cpuset.CPUSet {
+	return a.Difference(b).Union(b.Difference(a))
+}
@@ -0,0 +1,225 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package isolation
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/fswrap"
+)
+
+// known isolcpus flags, see https://www.kernel.org/doc/html/latest/admin-guide/kernel-parameters.html
+const (
+	IsolCPUsFlagNohz       = "nohz"
+	IsolCPUsFlagDomain     = "domain"
+	IsolCPUsFlagManagedIRQ = "managed_irq"
+)
+
+// CmdLine holds the isolation-related kernel boot parameters.
+// CPU sets are nil if the parameter is not present on the command line.
+type CmdLine struct {
+	Raw           string
+	IsolCPUs      *cpuset.CPUSet
+	IsolCPUsFlags []string
+	NohzFull      *cpuset.CPUSet
+	RCUNoCBs      *cpuset.CPUSet
+	IRQAffinity   *cpuset.CPUSet
+	SkewTick      string
+	IntelPState   string
+	Idle          string
+	// RCUNoCBsAll is set by a bare "rcu_nocbs", which lets any CPU offload its RCU callbacks.
+	// RCUNoCBs is nil then, because the CPUs actually offloading them are not known.
+	RCUNoCBsAll bool
+	// Errors collects the parameters we failed to parse, keyed by parameter name
+	Errors map[string]string
+}
+
+func (cl CmdLine) HasIsolCPUsFlag(flag string) bool {
+	for _, fl := range cl.IsolCPUsFlags {
+		if fl == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// SysFS holds the CPU sets the kernel reports under /sys/devices/system/cpu.
+// CPU sets are nil if the kernel does not expose the attribute.
+type SysFS struct {
+	Isolated *cpuset.CPUSet
+	NohzFull *cpuset.CPUSet
+	Offline  *cpuset.CPUSet
+	Present  *cpuset.CPUSet
+}
+
+type Info struct {
+	CmdLine CmdLine
+	SysFS   SysFS
+}
+
+// Online returns the CPUs which are present and not offline, or an empty set if unknown.
+func (info Info) Online() cpuset.CPUSet {
+	if info.SysFS.Present == nil {
+		return cpuset.New()
+	}
+	if info.SysFS.Offline == nil {
+		return *info.SysFS.Present
+	}
+	return info.SysFS.Present.Difference(*info.SysFS.Offline)
+}
+
+// NohzFull returns the nohz_full CPUs, preferring the runtime kernel view over the boot parameters.
+func (info Info) NohzFull() cpuset.CPUSet {
+	if info.SysFS.NohzFull != nil {
+		return *info.SysFS.NohzFull
+	}
+	if info.CmdLine.NohzFull != nil {
+		return *info.CmdLine.NohzFull
+	}
+	return cpuset.New()
+}
+
+// Isolated returns the isolated CPUs, preferring the runtime kernel view over the boot parameters.
+func (info Info) Isolated() cpuset.CPUSet {
+	if info.SysFS.Isolated != nil {
+		return *info.SysFS.Isolated
+	}
+	if info.CmdLine.IsolCPUs != nil {
+		return *info.CmdLine.IsolCPUs
+	}
+	return cpuset.New()
+}
+
+type Handler struct {
+	log        *log.Logger
+	procfsRoot string
+	sysfsRoot  string
+	fs         fswrap.FSWrapper
+}
+
+func New(logger *log.Logger, procfsRoot, sysfsRoot string) *Handler {
+	return &Handler{
+		log:        logger,
+		procfsRoot: procfsRoot,
+		sysfsRoot:  sysfsRoot,
+		fs:         fswrap.FSWrapper{Log: logger},
+	}
+}
+
+func (handler *Handler) ReadInfo() (Info, error) {
+	info := Info{}
+	data, err := handler.fs.ReadFile(filepath.Join(handler.procfsRoot, "cmdline"))
+	if err != nil {
+		return info, fmt.Errorf("error reading the kernel command line from %q: %v", handler.procfsRoot, err)
+	}
+	info.CmdLine = ParseCmdLine(string(data))
+
+	cpuRoot := filepath.Join(handler.sysfsRoot, "devices", "system", "cpu")
+	info.SysFS.Isolated = handler.readCPUList(filepath.Join(cpuRoot, "isolated"))
+	info.SysFS.NohzFull = handler.readCPUList(filepath.Join(cpuRoot, "nohz_full"))
+	info.SysFS.Offline = handler.readCPUList(filepath.Join(cpuRoot, "offline"))
+	info.SysFS.Present = handler.readCPUList(filepath.Join(cpuRoot, "present"))
+	return info, nil
+}
+
+// readCPUList returns nil if the file is missing or unparsable. Failures are not critical,
+// because older kernels don't expose all the attributes.
+func (handler *Handler) readCPUList(path string) *cpuset.CPUSet {
+	data, err := handler.fs.ReadFile(path)
+	if err != nil {
+		handler.log.Printf("Error reading %q: %v", path, err)
+		return nil
+	}
+	content := strings.TrimSpace(string(data))
+	// nohz_full reports "(null)" when not configured
+	if content == "(null)" {
+		content = ""
+	}
+	cpus, err := cpuset.Parse(content)
+	if err != nil {
+		handler.log.Printf("Error parsing cpulist in %q: %v", path, err)
+		return nil
+	}
+	return &cpus
+}
+
+func ParseCmdLine(cmdline string) CmdLine {
+	cl := CmdLine{
+		Raw: strings.TrimSpace(cmdline),
+	}
+	// the last occurrence wins, like the kernel does
+	for _, param := range strings.Fields(cl.Raw) {
+		key, val, _ := strings.Cut(param, "=")
+		switch key {
+		case "isolcpus":
+			flags, cpuList := splitIsolCPUs(val)
+			cl.IsolCPUsFlags = flags
+			cl.IsolCPUs = cl.parseCPUList(key, cpuList)
+		case "nohz_full":
+			cl.NohzFull = cl.parseCPUList(key, val)
+		case "rcu_nocbs":
+			cl.RCUNoCBsAll = val == ""
+			cl.RCUNoCBs = nil
+			if !cl.RCUNoCBsAll {
+				cl.RCUNoCBs = cl.parseCPUList(key, val)
+			}
+		case "irqaffinity":
+			cl.IRQAffinity = cl.parseCPUList(key, val)
+		case "skew_tick":
+			cl.SkewTick = val
+		case "intel_pstate":
+			cl.IntelPState = val
+		case "idle":
+			cl.Idle = val
+		}
+	}
+	return cl
+}
+
+func (cl *CmdLine) parseCPUList(key, val string) *cpuset.CPUSet {
+	cpus, err := cpuset.Parse(val)
+	if err != nil {
+		if cl.Errors == nil {
+			cl.Errors = make(map[string]string)
+		}
+		cl.Errors[key] = err.Error()
+		return nil
+	}
+	return &cpus
+}
+
+// splitIsolCPUs splits the "isolcpus=[flag-list,]<cpu-list>" value.
+// The flags and the cpu list share the comma as separator, so we need to
+// peel off the flags one by one.
+func splitIsolCPUs(val string) ([]string, string) {
+	var flags []string
+	items := strings.Split(val, ",")
+	idx := 0
+	for ; idx < len(items); idx++ {
+		item := items[idx]
+		if item != IsolCPUsFlagNohz && item != IsolCPUsFlagDomain && item != IsolCPUsFlagManagedIRQ {
+			break
+		}
+		flags = append(flags, item)
+	}
+	return flags, strings.Join(items[idx:], ",")
+}
@@ -0,0 +1,170 @@
+package isolation_test
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/isolation"
+)
+
+var nullLog = log.New(ioutil.Discard, "", 0)
+
+func TestParseCmdLine(t *testing.T) {
+	cl := isolation.ParseCmdLine("BOOT_IMAGE=/vmlinuz root=/dev/sda1 isolcpus=managed_irq,domain,2-7 nohz_full=2-7 rcu_nocbs=2-7 irqaffinity=0,1 skew_tick=1 intel_pstate=disable idle=poll\n")
+
+	if cl.IsolCPUs == nil || !cl.IsolCPUs.Equals(cpuset.New(2, 3, 4, 5, 6, 7)) {
+		t.Errorf("unexpected isolcpus: %v", cl.IsolCPUs)
+	}
+	if !reflect.DeepEqual(cl.IsolCPUsFlags, []string{"managed_irq", "domain"}) {
+		t.Errorf("unexpected isolcpus flags: %v", cl.IsolCPUsFlags)
+	}
+	if cl.NohzFull == nil || !cl.NohzFull.Equals(cpuset.New(2, 3, 4, 5, 6, 7)) {
+		t.Errorf("unexpected nohz_full: %v", cl.NohzFull)
+	}
+	if cl.IRQAffinity == nil || !cl.IRQAffinity.Equals(cpuset.New(0, 1)) {
+		t.Errorf("unexpected irqaffinity: %v", cl.IRQAffinity)
+	}
+	if cl.SkewTick != "1" || cl.IntelPState != "disable" || cl.Idle != "poll" {
+		t.Errorf("unexpected values: skew_tick=%q intel_pstate=%q idle=%q", cl.SkewTick, cl.IntelPState, cl.Idle)
+	}
+	if len(cl.Errors) > 0 {
+		t.Errorf("unexpected errors: %v", cl.Errors)
+	}
+}
+
+func TestParseCmdLineMissing(t *testing.T) {
+	cl := isolation.ParseCmdLine("BOOT_IMAGE=/vmlinuz root=/dev/sda1 nohz_full=2-x")
+	if cl.IsolCPUs != nil || cl.NohzFull != nil || cl.IRQAffinity != nil {
+		t.Errorf("unexpected values: %+v", cl)
+	}
+	if _, ok := cl.Errors["nohz_full"]; !ok {
+		t.Errorf("missing parse error for nohz_full: %v", cl.Errors)
+	}
+}
+
+func TestReadInfoAndCheck(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(rootDir) // clean up
+
+	procDir := filepath.Join(rootDir, "proc")
+	sysDir := filepath.Join(rootDir, "sys")
+	cpuDir := filepath.Join(sysDir, "devices", "system", "cpu")
+	if err := os.MkdirAll(procDir, 0755); err != nil {
+		t.Fatalf("Mkdir(%s) failed: %v", procDir, err)
+	}
+	if err := os.MkdirAll(cpuDir, 0755); err != nil {
+		t.Fatalf("Mkdir(%s) failed: %v", cpuDir, err)
+	}
+
+	files := map[string]string{
+		filepath.Join(procDir, "cmdline"):  "isolcpus=domain,2-5 nohz_full=2-7 irqaffinity=0-2\n",
+		filepath.Join(cpuDir, "isolated"):  "2-5\n",
+		filepath.Join(cpuDir, "nohz_full"): "2-7\n",
+		filepath.Join(cpuDir, "offline"):   "\n",
+		filepath.Join(cpuDir, "present"):   "0-7\n",
+	}
+	for path, content := range files {
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	ih := isolation.New(nullLog, procDir, sysDir)
+	info, err := ih.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo failed: %v", err)
+	}
+	if !info.Online().Equals(cpuset.New(0, 1, 2, 3, 4, 5, 6, 7)) {
+		t.Errorf("unexpected online cpus: %v", info.Online())
+	}
+
+	findings := isolation.Check(info, cpuset.New(2, 3, 4, 5, 6))
+	got := make(map[string][]int)
+	for _, finding := range findings {
+		got[finding.Code] = finding.CPUs
+	}
+
+	expected := map[string][]int{
+		"isolcpus-nohz_full-differ": {6, 7},
+		"isolcpus-no-managed_irq":   nil,
+		"irqaffinity-overlap":       {2},
+		"skew_tick-missing":         nil,
+		"cpulist-not-isolated":      {6},
+	}
+	for code, cpus := range expected {
+		gotCPUs, ok := got[code]
+		if !ok {
+			t.Errorf("missing finding %q in %v", code, findings)
+			continue
+		}
+		if !reflect.DeepEqual(gotCPUs, cpus) {
+			t.Errorf("finding %q: got cpus %v expected %v", code, gotCPUs, cpus)
+		}
+	}
+	for _, code := range []string{"isolcpus-mismatch", "nohz_full-mismatch", "cpulist-not-nohz_full", "no-housekeeping-cpus"} {
+		if _, ok := got[code]; ok {
+			t.Errorf("unexpected finding %q", code)
+		}
+	}
+}
+
+func TestCheckBareRCUNoCBs(t *testing.T) {
+	cl := isolation.ParseCmdLine("BOOT_IMAGE=/vmlinuz rcu_nocbs=0-1 isolcpus=2-3 nohz_full=2-3 irqaffinity=0-1 rcu_nocbs")
+	if !cl.RCUNoCBsAll || cl.RCUNoCBs != nil || len(cl.Errors) > 0 {
+		t.Fatalf("unexpected rcu_nocbs: all=%v cpus=%v errors=%v", cl.RCUNoCBsAll, cl.RCUNoCBs, cl.Errors)
+	}
+
+	present := cpuset.New(0, 1, 2, 3)
+	info := isolation.Info{
+		CmdLine: cl,
+		SysFS: isolation.SysFS{
+			Present: &present,
+		},
+	}
+	for _, finding := range isolation.Check(info, cpuset.New(2, 3)) {
+		if finding.Code == "rcu_nocbs-incomplete" || finding.Code == "cpulist-not-rcu_nocbs" {
+			t.Errorf("unexpected finding with bare rcu_nocbs: %v", finding)
+		}
+	}
+}
+
+func TestCheckSysFSMismatch(t *testing.T) {
+	isolCPUs := cpuset.New(2, 3)
+	sysIsolated := cpuset.New(2)
+	present := cpuset.New(0, 1, 2, 3)
+	info := isolation.Info{
+		CmdLine: isolation.CmdLine{
+			IsolCPUs: &isolCPUs,
+		},
+		SysFS: isolation.SysFS{
+			Isolated: &sysIsolated,
+			Present:  &present,
+		},
+	}
+
+	findings := isolation.Check(info, cpuset.New(0, 1, 2, 3))
+	found := false
+	for _, finding := range findings {
+		if finding.Code == "isolcpus-mismatch" {
+			found = true
+			if !reflect.DeepEqual(finding.CPUs, []int{3}) {
+				t.Errorf("unexpected cpus: %v", finding.CPUs)
+			}
+		}
+		if finding.Code == "cpulist-not-isolated" {
+			t.Errorf("unexpected cpulist check with all cpus selected")
+		}
+	}
+	if !found {
+		t.Errorf("missing isolcpus mismatch finding: %v", findings)
+	}
+}
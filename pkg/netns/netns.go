@@ -0,0 +1,76 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+// Package netns runs code in another network namespace, like the one of a pod.
+package netns
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// PathForPID returns the path of the network namespace of the process `pid`.
+func PathForPID(procfsRoot string, pid int) string {
+	return filepath.Join(procfsRoot, strconv.Itoa(pid), "ns", "net")
+}
+
+// SysFSForPID returns the sysfs the process `pid` sees. The container runtimes mount it from within
+// the network namespace of the container, so /sys/class/net lists the interfaces of the container.
+func SysFSForPID(procfsRoot string, pid int) string {
+	return filepath.Join(procfsRoot, strconv.Itoa(pid), "root", "sys")
+}
+
+// Do runs `fn` in the network namespace at `path`, or in the current one if `path` is empty.
+// Only the calling goroutine switches namespace: `fn` must not rely on other goroutines
+// (e.g. to open sockets), because they can run on other OS threads.
+// Note the sysfs mounts don't follow the network namespace, see SysFSForPID.
+func Do(path string, fn func() error) error {
+	if path == "" {
+		return fn()
+	}
+
+	target, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening the network namespace %q: %v", path, err)
+	}
+	defer target.Close()
+
+	runtime.LockOSThread()
+	origin, err := os.Open(fmt.Sprintf("/proc/self/task/%d/ns/net", unix.Gettid()))
+	if err != nil {
+		runtime.UnlockOSThread()
+		return fmt.Errorf("error opening the current network namespace: %v", err)
+	}
+	defer origin.Close()
+
+	if err := unix.Setns(int(target.Fd()), unix.CLONE_NEWNET); err != nil {
+		runtime.UnlockOSThread()
+		return fmt.Errorf("error entering the network namespace %q: %v", path, err)
+	}
+	fnErr := fn()
+	if err := unix.Setns(int(origin.Fd()), unix.CLONE_NEWNET); err != nil {
+		// we leave the thread locked, so the runtime terminates it when the goroutine exits,
+		// instead of reusing a thread in the wrong namespace
+		return fmt.Errorf("error restoring the network namespace: %v", err)
+	}
+	runtime.UnlockOSThread()
+	return fnErr
+}
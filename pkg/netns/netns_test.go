@@ -0,0 +1,54 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package netns_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openshift-kni/debug-tools/pkg/netns"
+)
+
+func TestPaths(t *testing.T) {
+	if got := netns.PathForPID("/host/proc", 4242); got != "/host/proc/4242/ns/net" {
+		t.Errorf("unexpected netns path: %q", got)
+	}
+	if got := netns.SysFSForPID("/host/proc", 4242); got != "/host/proc/4242/root/sys" {
+		t.Errorf("unexpected sysfs path: %q", got)
+	}
+}
+
+func TestDo(t *testing.T) {
+	errFake := errors.New("fake error")
+	called := false
+	err := netns.Do("", func() error {
+		called = true
+		return errFake
+	})
+	if !called || err != errFake {
+		t.Errorf("fn not run in the current namespace: called=%v err=%v", called, err)
+	}
+
+	called = false
+	err = netns.Do("/this/netns/does/not/exist", func() error {
+		called = true
+		return nil
+	})
+	if called || err == nil {
+		t.Errorf("fn run in a missing namespace: called=%v err=%v", called, err)
+	}
+}
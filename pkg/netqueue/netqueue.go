@@ -0,0 +1,196 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+// Package netqueue reads the packet steering settings of the network interface queues:
+// Receive Packet Steering (RPS) and Transmit Packet Steering (XPS).
+// See https://www.kernel.org/doc/Documentation/networking/scaling.txt
+package netqueue
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/cpumask"
+	"github.com/openshift-kni/debug-tools/pkg/fswrap"
+)
+
+type RxQueue struct {
+	Index int
+	// RPSCPUs are the cpus which process the packets received on the queue, in softirq context.
+	// Empty if RPS is disabled, so the packets are processed on the cpu which got the interrupt.
+	RPSCPUs cpuset.CPUSet
+	// RPSFlowCount is the size of the flow table of Receive Flow Steering (RFS)
+	RPSFlowCount uint64
+}
+
+func (rxq RxQueue) Name() string {
+	return fmt.Sprintf("rx-%d", rxq.Index)
+}
+
+type TxQueue struct {
+	Index int
+	// XPSCPUs are the cpus which transmit on the queue, nil if the kernel does not expose them
+	// (e.g. single queue devices)
+	XPSCPUs *cpuset.CPUSet
+	// XPSRxQueues are the indexes of the receive queues mapped to the queue, nil if not exposed
+	XPSRxQueues *cpuset.CPUSet
+}
+
+func (txq TxQueue) Name() string {
+	return fmt.Sprintf("tx-%d", txq.Index)
+}
+
+type Info struct {
+	Iface    string
+	RxQueues []RxQueue
+	TxQueues []TxQueue
+}
+
+type Handler struct {
+	log       *log.Logger
+	sysfsRoot string
+	fs        fswrap.FSWrapper
+}
+
+func New(logger *log.Logger, sysfsRoot string) *Handler {
+	return &Handler{
+		log:       logger,
+		sysfsRoot: sysfsRoot,
+		fs:        fswrap.FSWrapper{Log: logger},
+	}
+}
+
+func (handler *Handler) queuesDir(iface string) string {
+	return filepath.Join(handler.sysfsRoot, "class", "net", iface, "queues")
+}
+
+// ReadInfo reads the steering settings of the queues of `iface`, sorted by queue index.
+func (handler *Handler) ReadInfo(iface string) (Info, error) {
+	info := Info{
+		Iface: iface,
+	}
+	queuesDir := handler.queuesDir(iface)
+	entries, err := handler.fs.ReadDir(queuesDir)
+	if err != nil {
+		return info, err
+	}
+	for _, entry := range entries {
+		kind, idxStr, ok := strings.Cut(entry.Name(), "-")
+		if !ok {
+			continue
+		}
+		idx, err := strconv.Atoi(idxStr)
+		if err != nil {
+			continue
+		}
+		queueDir := filepath.Join(queuesDir, entry.Name())
+		switch kind {
+		case "rx":
+			rxq := RxQueue{
+				Index: idx,
+			}
+			rps, err := handler.readMask(filepath.Join(queueDir, "rps_cpus"))
+			if err != nil {
+				return info, err
+			}
+			rxq.RPSCPUs = *rps
+			if data, err := handler.fs.ReadFile(filepath.Join(queueDir, "rps_flow_cnt")); err == nil {
+				rxq.RPSFlowCount, err = strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+				if err != nil {
+					handler.log.Printf("Error parsing rps_flow_cnt of %s %s: %v", iface, entry.Name(), err)
+				}
+			}
+			info.RxQueues = append(info.RxQueues, rxq)
+		case "tx":
+			txq := TxQueue{
+				Index: idx,
+			}
+			// failures are not critical, because these attributes depend on the device
+			txq.XPSCPUs, err = handler.readMask(filepath.Join(queueDir, "xps_cpus"))
+			if err != nil {
+				handler.log.Printf("Error reading xps_cpus of %s %s: %v", iface, entry.Name(), err)
+			}
+			txq.XPSRxQueues, err = handler.readMask(filepath.Join(queueDir, "xps_rxqs"))
+			if err != nil {
+				handler.log.Printf("Error reading xps_rxqs of %s %s: %v", iface, entry.Name(), err)
+			}
+			info.TxQueues = append(info.TxQueues, txq)
+		}
+	}
+	sort.Slice(info.RxQueues, func(i, j int) bool { return info.RxQueues[i].Index < info.RxQueues[j].Index })
+	sort.Slice(info.TxQueues, func(i, j int) bool { return info.TxQueues[i].Index < info.TxQueues[j].Index })
+	return info, nil
+}
+
+func (handler *Handler) readMask(path string) (*cpuset.CPUSet, error) {
+	data, err := handler.fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cpus, err := cpumask.Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %q: %v", path, err)
+	}
+	return &cpus, nil
+}
+
+// Write is a write of a queue cpumask attribute. The cpus are in the cpulist format, Mask is what to write.
+type Write struct {
+	Path  string `json:"path"`
+	Iface string `json:"iface"`
+	Queue string `json:"queue"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Mask  string `json:"mask"`
+}
+
+// String returns the write as a shell command
+func (wr Write) String() string {
+	to := wr.To
+	if to == "" {
+		to = "disabled"
+	}
+	return fmt.Sprintf("echo %s > %s # %s -> %s", wr.Mask, wr.Path, wr.From, to)
+}
+
+// Plan computes the writes needed to keep the RPS processing off the `cpus`. The cpus are
+// removed from the RPS cpumasks; if none is left, RPS is disabled, so the packets are processed
+// where the interrupt lands, which is in turn controlled by the IRQ affinity (see irqaff plan).
+// XPS is left alone: it only selects the queue the cpus transmit on, so it adds no work on them.
+func (handler *Handler) Plan(info Info, cpus cpuset.CPUSet) []Write {
+	var writes []Write
+	for _, rxq := range info.RxQueues {
+		if rxq.RPSCPUs.Intersection(cpus).Size() == 0 {
+			continue
+		}
+		target := rxq.RPSCPUs.Difference(cpus)
+		writes = append(writes, Write{
+			Path:  filepath.Join(handler.queuesDir(info.Iface), rxq.Name(), "rps_cpus"),
+			Iface: info.Iface,
+			Queue: rxq.Name(),
+			From:  rxq.RPSCPUs.String(),
+			To:    target.String(),
+			Mask:  cpumask.Format(target),
+		})
+	}
+	return writes
+}
@@ -0,0 +1,124 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package netqueue_test
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/internal/fakefs"
+	"github.com/openshift-kni/debug-tools/pkg/netqueue"
+)
+
+var nullLog = log.New(ioutil.Discard, "", 0)
+
+func TestReadInfo(t *testing.T) {
+	sysDir := makeFakeSysFS(t)
+	defer os.RemoveAll(sysDir) // clean up
+
+	info, err := netqueue.New(nullLog, sysDir).ReadInfo("ens1f0")
+	if err != nil {
+		t.Fatalf("ReadInfo failed: %v", err)
+	}
+	if len(info.RxQueues) != 3 || len(info.TxQueues) != 2 {
+		t.Fatalf("unexpected queues: %+v", info)
+	}
+	var rpsTestCases = []struct {
+		queue     string
+		cpus      cpuset.CPUSet
+		flowCount uint64
+	}{
+		{"rx-0", cpuset.New(0, 1), 4096},
+		{"rx-1", cpuset.New(), 0},
+		{"rx-2", cpuset.New(2, 3, 32), 0},
+	}
+	for idx, tt := range rpsTestCases {
+		t.Run(tt.queue, func(t *testing.T) {
+			rxq := info.RxQueues[idx]
+			if rxq.Name() != tt.queue || !rxq.RPSCPUs.Equals(tt.cpus) || rxq.RPSFlowCount != tt.flowCount {
+				t.Errorf("unexpected queue: %+v", rxq)
+			}
+		})
+	}
+
+	txq := info.TxQueues[0]
+	if txq.XPSCPUs == nil || !txq.XPSCPUs.Equals(cpuset.New(0, 1, 2, 3)) {
+		t.Errorf("unexpected xps cpus: %v", txq.XPSCPUs)
+	}
+	if txq.XPSRxQueues == nil || !txq.XPSRxQueues.Equals(cpuset.New(0)) {
+		t.Errorf("unexpected xps rx queues: %v", txq.XPSRxQueues)
+	}
+	if txq := info.TxQueues[1]; txq.XPSCPUs != nil {
+		t.Errorf("unexpected xps cpus for %s: %v", txq.Name(), txq.XPSCPUs)
+	}
+
+	if _, err := netqueue.New(nullLog, sysDir).ReadInfo("ens9"); err == nil {
+		t.Errorf("missing interface not detected")
+	}
+}
+
+func TestPlan(t *testing.T) {
+	sysDir := makeFakeSysFS(t)
+	defer os.RemoveAll(sysDir) // clean up
+
+	nh := netqueue.New(nullLog, sysDir)
+	info, err := nh.ReadInfo("ens1f0")
+	if err != nil {
+		t.Fatalf("ReadInfo failed: %v", err)
+	}
+
+	queuesDir := filepath.Join(sysDir, "class", "net", "ens1f0", "queues")
+	got := nh.Plan(info, cpuset.New(1, 2, 3))
+	expected := []netqueue.Write{
+		{Path: filepath.Join(queuesDir, "rx-0", "rps_cpus"), Iface: "ens1f0", Queue: "rx-0", From: "0-1", To: "0", Mask: "00000001"},
+		{Path: filepath.Join(queuesDir, "rx-2", "rps_cpus"), Iface: "ens1f0", Queue: "rx-2", From: "2-3,32", To: "32", Mask: "00000001,00000000"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected plan\ngot=%+v\nexpected=%+v", got, expected)
+	}
+
+	got = nh.Plan(info, cpuset.New(0, 1))
+	if len(got) != 1 || got[0].Mask != "0" || got[0].String() != "echo 0 > "+got[0].Path+" # 0-1 -> disabled" {
+		t.Errorf("unexpected plan disabling RPS: %+v", got)
+	}
+}
+
+func makeFakeSysFS(t *testing.T) string {
+	t.Helper()
+	rootDir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	queuesDir := filepath.Join(rootDir, "class", "net", "ens1f0", "queues")
+	fakefs.WriteFile(t, filepath.Join(queuesDir, "rx-0", "rps_cpus"), "00000000,00000003\n")
+	fakefs.WriteFile(t, filepath.Join(queuesDir, "rx-0", "rps_flow_cnt"), "4096\n")
+	fakefs.WriteFile(t, filepath.Join(queuesDir, "rx-1", "rps_cpus"), "00000000,00000000\n")
+	fakefs.WriteFile(t, filepath.Join(queuesDir, "rx-1", "rps_flow_cnt"), "0\n")
+	fakefs.WriteFile(t, filepath.Join(queuesDir, "rx-2", "rps_cpus"), "00000001,0000000c\n")
+	fakefs.WriteFile(t, filepath.Join(queuesDir, "rx-2", "rps_flow_cnt"), "0\n")
+	fakefs.WriteFile(t, filepath.Join(queuesDir, "tx-0", "xps_cpus"), "00000000,0000000f\n")
+	fakefs.WriteFile(t, filepath.Join(queuesDir, "tx-0", "xps_rxqs"), "1\n")
+	fakefs.WriteFile(t, filepath.Join(queuesDir, "tx-0", "tx_maxrate"), "0\n")
+	fakefs.WriteFile(t, filepath.Join(queuesDir, "tx-1", "tx_maxrate"), "0\n")
+	return rootDir
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package numa
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/fswrap"
+)
+
+// NodeCPUs maps NUMA node ids to the CPUs belonging to that node
+type NodeCPUs map[int]cpuset.CPUSet
+
+// NodesOf returns the sorted ids of the nodes owning any of the given cpus
+func (nc NodeCPUs) NodesOf(cpus cpuset.CPUSet) []int {
+	var nodes []int
+	for node, nodeCPUs := range nc {
+		if nodeCPUs.Intersection(cpus).Size() > 0 {
+			nodes = append(nodes, node)
+		}
+	}
+	return cpuset.New(nodes...).List()
+}
+
+type Handler struct {
+	log       *log.Logger
+	sysfsRoot string
+	fs        fswrap.FSWrapper
+}
+
+func New(logger *log.Logger, sysfsRoot string) *Handler {
+	return &Handler{
+		log:       logger,
+		sysfsRoot: sysfsRoot,
+		fs:        fswrap.FSWrapper{Log: logger},
+	}
+}
+
+// ReadNodeCPUs reads the CPUs of each NUMA node from sysfs.
+// Systems without NUMA support are reported as a single node 0 owning all the online CPUs.
+func (handler *Handler) ReadNodeCPUs() (NodeCPUs, error) {
+	res := make(NodeCPUs)
+	nodeRoot := filepath.Join(handler.sysfsRoot, "devices", "system", "node")
+	entries, err := handler.fs.ReadDir(nodeRoot)
+	if err != nil {
+		handler.log.Printf("Error reading %q: %v", nodeRoot, err)
+		cpus, err := handler.readCPUList(filepath.Join(handler.sysfsRoot, "devices", "system", "cpu", "online"))
+		if err != nil {
+			return res, err
+		}
+		res[0] = cpus
+		return res, nil
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, "node") {
+			continue
+		}
+		node, err := strconv.Atoi(strings.TrimPrefix(name, "node"))
+		if err != nil {
+			// doesn't look like a node
+			continue
+		}
+		cpus, err := handler.readCPUList(filepath.Join(nodeRoot, name, "cpulist"))
+		if err != nil {
+			return res, err
+		}
+		res[node] = cpus
+	}
+	return res, nil
+}
+
+func (handler *Handler) readCPUList(path string) (cpuset.CPUSet, error) {
+	data, err := handler.fs.ReadFile(path)
+	if err != nil {
+		return cpuset.New(), err
+	}
+	cpus, err := cpuset.Parse(strings.TrimSpace(string(data)))
+	if err != nil {
+		return cpus, fmt.Errorf("error parsing cpulist in %q: %v", path, err)
+	}
+	return cpus, nil
+}
@@ -0,0 +1,87 @@
+package numa_test
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/numa"
+)
+
+var nullLog = log.New(ioutil.Discard, "", 0)
+
+func TestReadNodeCPUs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(dir) // clean up
+
+	nodeRoot := filepath.Join(dir, "devices", "system", "node")
+	for name, cpulist := range map[string]string{
+		"node0": "0-3,8-11\n",
+		"node1": "4-7,12-15\n",
+	} {
+		if err := os.MkdirAll(filepath.Join(nodeRoot, name), 0755); err != nil {
+			t.Fatalf("populating temp dir %v", err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(nodeRoot, name, "cpulist"), []byte(cpulist), 0644); err != nil {
+			t.Fatalf("populating temp dir %v", err)
+		}
+	}
+	// not a node
+	if err := os.MkdirAll(filepath.Join(nodeRoot, "power"), 0755); err != nil {
+		t.Fatalf("populating temp dir %v", err)
+	}
+
+	nodeCPUs, err := numa.New(nullLog, dir).ReadNodeCPUs()
+	if err != nil {
+		t.Fatalf("ReadNodeCPUs failed: %v", err)
+	}
+	expected := numa.NodeCPUs{
+		0: cpuset.New(0, 1, 2, 3, 8, 9, 10, 11),
+		1: cpuset.New(4, 5, 6, 7, 12, 13, 14, 15),
+	}
+	if !reflect.DeepEqual(nodeCPUs, expected) {
+		t.Errorf("unexpected node cpus: got=%v expected=%v", nodeCPUs, expected)
+	}
+
+	if nodes := nodeCPUs.NodesOf(cpuset.New(2, 3)); !reflect.DeepEqual(nodes, []int{0}) {
+		t.Errorf("unexpected nodes: %v", nodes)
+	}
+	if nodes := nodeCPUs.NodesOf(cpuset.New(3, 4)); !reflect.DeepEqual(nodes, []int{0, 1}) {
+		t.Errorf("unexpected nodes: %v", nodes)
+	}
+}
+
+func TestReadNodeCPUsNoNUMA(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(dir) // clean up
+
+	cpuRoot := filepath.Join(dir, "devices", "system", "cpu")
+	if err := os.MkdirAll(cpuRoot, 0755); err != nil {
+		t.Fatalf("populating temp dir %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(cpuRoot, "online"), []byte("0-3\n"), 0644); err != nil {
+		t.Fatalf("populating temp dir %v", err)
+	}
+
+	nodeCPUs, err := numa.New(nullLog, dir).ReadNodeCPUs()
+	if err != nil {
+		t.Fatalf("ReadNodeCPUs failed: %v", err)
+	}
+	expected := numa.NodeCPUs{
+		0: cpuset.New(0, 1, 2, 3),
+	}
+	if !reflect.DeepEqual(nodeCPUs, expected) {
+		t.Errorf("unexpected node cpus: got=%v expected=%v", nodeCPUs, expected)
+	}
+}
@@ -0,0 +1,223 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package pcidev
+
+import (
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-kni/debug-tools/pkg/fswrap"
+)
+
+type Device struct {
+	// Address is the PCI address in the domain:bus:device.function form, like "0000:3b:00.0"
+	Address string `json:"address"`
+	Driver  string `json:"driver,omitempty"`
+	// NUMANode is -1 if the platform does not report the device locality
+	NUMANode int `json:"numaNode"`
+	// Ifaces are the network interfaces backed by the device, if any
+	Ifaces []string `json:"ifaces,omitempty"`
+	// IRQs are the MSI/MSI-X interrupts of the device
+	IRQs []int `json:"irqs,omitempty"`
+	// PrimaryIRQ is the interrupt from the irq attribute: the INTx line, which can be shared
+	// among devices, or the first vector if MSI is enabled. We use it only if msi_irqs is not available.
+	PrimaryIRQ int `json:"primaryIRQ,omitempty"`
+}
+
+// PCI address -> device
+type Devices map[string]Device
+
+// IRQOwners returns the PCI address of the device owning each IRQ.
+// Primary IRQs shared among devices are attributed to the device with the lowest address.
+func (devs Devices) IRQOwners() map[int]string {
+	addrs := make([]string, 0, len(devs))
+	for addr := range devs {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	res := make(map[int]string)
+	for _, addr := range addrs {
+		for _, irq := range devs[addr].IRQs {
+			res[irq] = addr
+		}
+	}
+	for _, addr := range addrs {
+		irq := devs[addr].PrimaryIRQ
+		if _, ok := res[irq]; ok || irq == 0 {
+			continue
+		}
+		res[irq] = addr
+	}
+	return res
+}
+
+// Find returns the devices whose PCI address is `addr` or the devices backing the network
+// interface `iface`. An empty value matches anything. The PCI domain can be omitted.
+func (devs Devices) Find(addr, iface string) []Device {
+	var res []Device
+	for devAddr, dev := range devs {
+		if addr != "" && devAddr != addr && strings.TrimPrefix(devAddr, "0000:") != addr {
+			continue
+		}
+		if iface != "" && !contains(dev.Ifaces, iface) {
+			continue
+		}
+		res = append(res, dev)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Address < res[j].Address
+	})
+	return res
+}
+
+// IfaceFor returns the network interface the IRQ handler named `action` serves, or "" if none does.
+// Drivers usually name the handlers after the interface, like "ens1f0-TxRx-7" or "i40e-ens1f0-TxRx-7",
+// so we look for the interface as a dash-separated token of the name: a plain substring match would
+// pick "ens1f0" for "ens1f0np0-TxRx-7". The misc and async vectors, like "i40e-0000:3b:00.0:misc",
+// serve no interface.
+func (dev Device) IfaceFor(action string) string {
+	for _, iface := range dev.Ifaces {
+		if action == iface || strings.HasPrefix(action, iface+"-") || strings.HasSuffix(action, "-"+iface) || strings.Contains(action, "-"+iface+"-") {
+			return iface
+		}
+	}
+	return ""
+}
+
+// QueueName extracts the queue from the IRQ handler name `action`, like
+// "TxRx-7" from "ens1f0-TxRx-7" or "i40e-ens1f0-TxRx-7", and "mlx5_comp7" from "mlx5_comp7@pci:0000:3b:00.0".
+func QueueName(action, iface string) string {
+	if off := strings.Index(action, "@pci:"); off > 0 {
+		return action[:off]
+	}
+	if iface != "" {
+		if off := strings.Index(action, iface+"-"); off >= 0 {
+			return action[off+len(iface)+1:]
+		}
+	}
+	return action
+}
+
+type Handler struct {
+	log       *log.Logger
+	sysfsRoot string
+	fs        fswrap.FSWrapper
+}
+
+func New(logger *log.Logger, sysfsRoot string) *Handler {
+	return &Handler{
+		log:       logger,
+		sysfsRoot: sysfsRoot,
+		fs:        fswrap.FSWrapper{Log: logger},
+	}
+}
+
+func (handler *Handler) ReadDevices() (Devices, error) {
+	devs := make(Devices)
+	devRoot := filepath.Join(handler.sysfsRoot, "bus", "pci", "devices")
+	entries, err := handler.fs.ReadDir(devRoot)
+	if err != nil {
+		return devs, err
+	}
+	for _, entry := range entries {
+		addr := entry.Name()
+		devs[addr] = handler.readDevice(filepath.Join(devRoot, addr), addr)
+	}
+
+	// failures are not critical, we just lose the interface names
+	if err := handler.readNetIfaces(devs); err != nil {
+		handler.log.Printf("Error reading the network interfaces: %v", err)
+	}
+	return devs, nil
+}
+
+func (handler *Handler) readDevice(devDir, addr string) Device {
+	dev := Device{
+		Address:  addr,
+		NUMANode: -1,
+	}
+	if data, err := handler.fs.ReadFile(filepath.Join(devDir, "numa_node")); err == nil {
+		if node, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil {
+			dev.NUMANode = node
+		}
+	}
+	if driverPath, err := handler.fs.EvalSymlinks(filepath.Join(devDir, "driver")); err == nil {
+		dev.Driver = filepath.Base(driverPath)
+	}
+
+	// see https://www.kernel.org/doc/Documentation/ABI/testing/sysfs-bus-pci
+	if entries, err := handler.fs.ReadDir(filepath.Join(devDir, "msi_irqs")); err == nil {
+		for _, entry := range entries {
+			irq, err := strconv.Atoi(entry.Name())
+			if err != nil {
+				continue
+			}
+			dev.IRQs = append(dev.IRQs, irq)
+		}
+		sort.Ints(dev.IRQs)
+	}
+	if len(dev.IRQs) == 0 {
+		// no MSI info, the device may use a legacy interrupt line
+		if data, err := handler.fs.ReadFile(filepath.Join(devDir, "irq")); err == nil {
+			if irq, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil && irq > 0 {
+				dev.PrimaryIRQ = irq
+			}
+		}
+	}
+	return dev
+}
+
+func (handler *Handler) readNetIfaces(devs Devices) error {
+	netRoot := filepath.Join(handler.sysfsRoot, "class", "net")
+	entries, err := handler.fs.ReadDir(netRoot)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		iface := entry.Name()
+		devPath, err := handler.fs.EvalSymlinks(filepath.Join(netRoot, iface, "device"))
+		if err != nil {
+			// virtual interfaces have no device
+			continue
+		}
+		// the device may be a child of the PCI device, like virtio devices, so we walk up the path
+		for ; devPath != "/" && devPath != "."; devPath = filepath.Dir(devPath) {
+			dev, ok := devs[filepath.Base(devPath)]
+			if !ok {
+				continue
+			}
+			dev.Ifaces = append(dev.Ifaces, iface)
+			sort.Strings(dev.Ifaces)
+			devs[dev.Address] = dev
+			break
+		}
+	}
+	return nil
+}
+
+func contains(items []string, item string) bool {
+	for _, it := range items {
+		if it == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package pcidev_test
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openshift-kni/debug-tools/internal/fakefs"
+	"github.com/openshift-kni/debug-tools/pkg/pcidev"
+)
+
+var nullLog = log.New(ioutil.Discard, "", 0)
+
+func TestReadDevices(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(dir) // clean up
+
+	devicesDir := filepath.Join(dir, "devices", "pci0000:3a")
+	nicDir := filepath.Join(devicesDir, "0000:3b:00.0")
+	nvmeDir := filepath.Join(devicesDir, "0000:5e:00.0")
+	fakefs.Mkdir(t, filepath.Join(nicDir, "msi_irqs", "153"))
+	fakefs.Mkdir(t, filepath.Join(nicDir, "msi_irqs", "154"))
+	fakefs.Mkdir(t, filepath.Join(nicDir, "net", "ens1f0"))
+	fakefs.WriteFile(t, filepath.Join(nicDir, "numa_node"), "0\n")
+	fakefs.WriteFile(t, filepath.Join(nicDir, "irq"), "153\n")
+	fakefs.Mkdir(t, nvmeDir)
+	fakefs.WriteFile(t, filepath.Join(nvmeDir, "numa_node"), "-1\n")
+	fakefs.WriteFile(t, filepath.Join(nvmeDir, "irq"), "16\n")
+
+	fakefs.Mkdir(t, filepath.Join(dir, "bus", "pci", "drivers", "i40e"))
+	fakefs.Symlink(t, "../../../bus/pci/drivers/i40e", filepath.Join(nicDir, "driver"))
+
+	fakefs.Mkdir(t, filepath.Join(dir, "bus", "pci", "devices"))
+	fakefs.Symlink(t, "../../../devices/pci0000:3a/0000:3b:00.0", filepath.Join(dir, "bus", "pci", "devices", "0000:3b:00.0"))
+	fakefs.Symlink(t, "../../../devices/pci0000:3a/0000:5e:00.0", filepath.Join(dir, "bus", "pci", "devices", "0000:5e:00.0"))
+
+	fakefs.Mkdir(t, filepath.Join(dir, "class", "net", "lo"))
+	fakefs.Symlink(t, "../../devices/pci0000:3a/0000:3b:00.0/net/ens1f0", filepath.Join(dir, "class", "net", "ens1f0"))
+	fakefs.Symlink(t, "../../../0000:3b:00.0", filepath.Join(nicDir, "net", "ens1f0", "device"))
+
+	devs, err := pcidev.New(nullLog, dir).ReadDevices()
+	if err != nil {
+		t.Fatalf("ReadDevices failed: %v", err)
+	}
+
+	expected := pcidev.Devices{
+		"0000:3b:00.0": pcidev.Device{
+			Address:    "0000:3b:00.0",
+			Driver:     "i40e",
+			NUMANode:   0,
+			Ifaces:     []string{"ens1f0"},
+			IRQs:       []int{153, 154},
+			PrimaryIRQ: 0,
+		},
+		"0000:5e:00.0": pcidev.Device{
+			Address:    "0000:5e:00.0",
+			NUMANode:   -1,
+			PrimaryIRQ: 16,
+		},
+	}
+	if !reflect.DeepEqual(devs, expected) {
+		t.Errorf("unexpected devices: got=%+v expected=%+v", devs, expected)
+	}
+
+	owners := devs.IRQOwners()
+	expectedOwners := map[int]string{
+		153: "0000:3b:00.0",
+		154: "0000:3b:00.0",
+		16:  "0000:5e:00.0",
+	}
+	if !reflect.DeepEqual(owners, expectedOwners) {
+		t.Errorf("unexpected owners: got=%v expected=%v", owners, expectedOwners)
+	}
+
+	found := devs.Find("", "ens1f0")
+	if len(found) != 1 || found[0].Address != "0000:3b:00.0" {
+		t.Errorf("unexpected devices for ens1f0: %v", found)
+	}
+	found = devs.Find("5e:00.0", "")
+	if len(found) != 1 || found[0].Address != "0000:5e:00.0" {
+		t.Errorf("unexpected devices for 5e:00.0: %v", found)
+	}
+}
+
+func TestIfaceFor(t *testing.T) {
+	dev := pcidev.Device{
+		Address: "0000:3b:00.0",
+		Ifaces:  []string{"ens1f0", "ens1f0np0"},
+	}
+	testCases := []struct {
+		action   string
+		expected string
+	}{
+		{"ens1f0-TxRx-7", "ens1f0"},
+		{"ens1f0np0-TxRx-7", "ens1f0np0"},
+		{"i40e-ens1f0np0-TxRx-7", "ens1f0np0"},
+		{"ice-ens1f0", "ens1f0"},
+		{"i40e-0000:3b:00.0:misc", ""},
+		{"mlx5_comp7@pci:0000:3b:00.0", ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.action, func(t *testing.T) {
+			got := dev.IfaceFor(tc.action)
+			if got != tc.expected {
+				t.Errorf("got=%q expected=%q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestQueueName(t *testing.T) {
+	testCases := []struct {
+		action   string
+		iface    string
+		expected string
+	}{
+		{"ens1f0-TxRx-7", "ens1f0", "TxRx-7"},
+		{"i40e-ens1f0-TxRx-7", "ens1f0", "TxRx-7"},
+		{"mlx5_comp7@pci:0000:3b:00.0", "ens1f0", "mlx5_comp7"},
+		{"nvme0q3", "", "nvme0q3"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.action, func(t *testing.T) {
+			got := pcidev.QueueName(tc.action, tc.iface)
+			if got != tc.expected {
+				t.Errorf("got=%q expected=%q", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package powermgmt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/isolation"
+)
+
+// cpuGroup collects the cpus sharing a finding, and the distinct values which triggered it
+type cpuGroup struct {
+	cpus   []int
+	values map[string]bool
+}
+
+func (cg *cpuGroup) add(cpuid int, vals ...string) {
+	cg.cpus = append(cg.cpus, cpuid)
+	if cg.values == nil {
+		cg.values = make(map[string]bool)
+	}
+	for _, val := range vals {
+		cg.values[val] = true
+	}
+}
+
+func (cg *cpuGroup) CPUs() cpuset.CPUSet {
+	return cpuset.New(cg.cpus...)
+}
+
+func (cg *cpuGroup) Values() string {
+	var vals []string
+	for val := range cg.values {
+		vals = append(vals, val)
+	}
+	sort.Strings(vals)
+	return strings.Join(vals, ", ")
+}
+
+// Check reports the isolated cpus whose power management settings can add latency: cpus not on
+// the performance governor or which can enter idle states with exit latency above `maxLatencyUs`,
+// and cpus which have been thermally throttled.
+func Check(infos []CPUInfo, isolated cpuset.CPUSet, maxLatencyUs uint64) []isolation.Finding {
+	var ck isolation.Findings
+	if isolated.Size() == 0 {
+		ck.Add(isolation.SeverityInfo, "no-isolated-cpus", cpuset.New(), "no isolated cpus to check")
+		return ck
+	}
+
+	var noFreq, governor, epp, freqRange, noIdle, deepIdle, throttled cpuGroup
+	for _, ci := range infos {
+		if !isolated.Contains(ci.CPU) {
+			continue
+		}
+		if ci.Freq == nil {
+			noFreq.add(ci.CPU)
+		} else {
+			if ci.Freq.Governor != GovernorPerformance {
+				governor.add(ci.CPU, ci.Freq.Governor)
+			}
+			if ci.Freq.EnergyPerfPreference != "" && ci.Freq.EnergyPerfPreference != GovernorPerformance {
+				epp.add(ci.CPU, ci.Freq.EnergyPerfPreference)
+			}
+			if ci.Freq.MinKHz < ci.Freq.MaxKHz {
+				freqRange.add(ci.CPU, fmt.Sprintf("%d-%d MHz", ci.Freq.MinKHz/1000, ci.Freq.MaxKHz/1000))
+			}
+		}
+		if len(ci.IdleStates) == 0 {
+			noIdle.add(ci.CPU)
+		} else if deep := ci.DeepIdleStates(maxLatencyUs); len(deep) > 0 {
+			var names []string
+			for _, st := range deep {
+				names = append(names, fmt.Sprintf("%s (%dus)", st.Name, st.LatencyUs))
+			}
+			deepIdle.add(ci.CPU, names...)
+		}
+		if ci.Throttle != nil && ci.Throttle.CoreCount+ci.Throttle.PackageCount > 0 {
+			throttled.add(ci.CPU)
+		}
+	}
+
+	if len(noFreq.cpus) > 0 {
+		ck.Add(isolation.SeverityInfo, "cpufreq-missing", noFreq.CPUs(), "cpufreq is not available, cannot check the frequency scaling")
+	}
+	if len(governor.cpus) > 0 {
+		ck.Add(isolation.SeverityWarning, "governor-not-performance", governor.CPUs(),
+			"isolated cpus use the %s governor instead of %s", governor.Values(), GovernorPerformance)
+	}
+	if len(epp.cpus) > 0 {
+		ck.Add(isolation.SeverityWarning, "epp-not-performance", epp.CPUs(),
+			"isolated cpus have energy_performance_preference %s instead of %s", epp.Values(), GovernorPerformance)
+	}
+	if len(freqRange.cpus) > 0 {
+		ck.Add(isolation.SeverityInfo, "frequency-not-pinned", freqRange.CPUs(),
+			"isolated cpus can scale their frequency in %s", freqRange.Values())
+	}
+	if len(noIdle.cpus) > 0 {
+		ck.Add(isolation.SeverityInfo, "cpuidle-missing", noIdle.CPUs(), "cpuidle is not available, cannot check the idle states")
+	}
+	if len(deepIdle.cpus) > 0 {
+		ck.Add(isolation.SeverityWarning, "deep-idle-states", deepIdle.CPUs(),
+			"isolated cpus can enter idle states with exit latency above %dus: %s", maxLatencyUs, deepIdle.Values())
+	}
+	if len(throttled.cpus) > 0 {
+		ck.Add(isolation.SeverityWarning, "thermal-throttled", throttled.CPUs(), "isolated cpus have been thermally throttled since boot")
+	}
+	return ck
+}
@@ -0,0 +1,85 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package powermgmt
+
+import (
+	"time"
+
+	"github.com/openshift-kni/debug-tools/pkg/counter"
+)
+
+type IdleStateDelta struct {
+	Name   string `json:"name"`
+	Usage  uint64 `json:"usage"`
+	TimeUs uint64 `json:"timeUs"`
+	// Residency is the percentage of the elapsed time spent in the state
+	Residency float64 `json:"residency"`
+}
+
+type CPUDelta struct {
+	CPU        int              `json:"cpu"`
+	IdleStates []IdleStateDelta `json:"idleStates,omitempty"`
+	// ThrottleEvents counts both the core and the package throttle events
+	ThrottleEvents uint64 `json:"throttleEvents"`
+	ThrottleTimeMs uint64 `json:"throttleTimeMs"`
+}
+
+// Delta computes the idle state residencies and the throttle events from `prev` to `last`,
+// `elapsed` time apart. Cpus or idle states missing in either sample are skipped.
+func Delta(prev, last []CPUInfo, elapsed time.Duration) []CPUDelta {
+	prevByCPU := make(map[int]CPUInfo)
+	for _, ci := range prev {
+		prevByCPU[ci.CPU] = ci
+	}
+	var res []CPUDelta
+	for _, lastInfo := range last {
+		prevInfo, ok := prevByCPU[lastInfo.CPU]
+		if !ok {
+			continue
+		}
+		cd := CPUDelta{
+			CPU: lastInfo.CPU,
+		}
+		prevStates := make(map[int]IdleState)
+		for _, st := range prevInfo.IdleStates {
+			prevStates[st.Index] = st
+		}
+		for _, lastState := range lastInfo.IdleStates {
+			prevState, ok := prevStates[lastState.Index]
+			if !ok {
+				continue
+			}
+			sd := IdleStateDelta{
+				Name:   lastState.Name,
+				Usage:  counter.Delta(prevState.Usage, lastState.Usage),
+				TimeUs: counter.Delta(prevState.TimeUs, lastState.TimeUs),
+			}
+			if elapsed > 0 {
+				sd.Residency = float64(sd.TimeUs) * 100.0 / float64(elapsed.Microseconds())
+			}
+			cd.IdleStates = append(cd.IdleStates, sd)
+		}
+		if prevInfo.Throttle != nil && lastInfo.Throttle != nil {
+			cd.ThrottleEvents = counter.Delta(prevInfo.Throttle.CoreCount, lastInfo.Throttle.CoreCount) +
+				counter.Delta(prevInfo.Throttle.PackageCount, lastInfo.Throttle.PackageCount)
+			cd.ThrottleTimeMs = counter.Delta(prevInfo.Throttle.CoreTimeMs, lastInfo.Throttle.CoreTimeMs) +
+				counter.Delta(prevInfo.Throttle.PackageTimeMs, lastInfo.Throttle.PackageTimeMs)
+		}
+		res = append(res, cd)
+	}
+	return res
+}
@@ -0,0 +1,207 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package powermgmt
+
+import (
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/fswrap"
+)
+
+const GovernorPerformance = "performance"
+
+// Freq are the cpufreq settings of a cpu. Frequencies are in kHz, zero if not reported.
+type Freq struct {
+	Driver   string `json:"driver"`
+	Governor string `json:"governor"`
+	MinKHz   uint64 `json:"minKHz"`
+	MaxKHz   uint64 `json:"maxKHz"`
+	CurKHz   uint64 `json:"curKHz"`
+	// HWMaxKHz is the maximum frequency the hardware supports
+	HWMaxKHz uint64 `json:"hwMaxKHz"`
+	// EnergyPerfPreference is empty if the driver does not support it (e.g. acpi-cpufreq)
+	EnergyPerfPreference string `json:"energyPerfPreference,omitempty"`
+}
+
+// IdleState is a cpuidle state of a cpu. Usage and Time are cumulative since boot.
+type IdleState struct {
+	Index     int    `json:"index"`
+	Name      string `json:"name"`
+	LatencyUs uint64 `json:"latencyUs"`
+	Disabled  bool   `json:"disabled"`
+	Usage     uint64 `json:"usage"`
+	TimeUs    uint64 `json:"timeUs"`
+}
+
+// Throttle are the thermal throttling counters of a cpu, cumulative since boot.
+type Throttle struct {
+	CoreCount     uint64 `json:"coreCount"`
+	CoreTimeMs    uint64 `json:"coreTimeMs"`
+	PackageCount  uint64 `json:"packageCount"`
+	PackageTimeMs uint64 `json:"packageTimeMs"`
+}
+
+// CPUInfo holds the power management state of a cpu. Freq and Throttle are nil if the kernel
+// does not expose them, IdleStates is empty if cpuidle is not available.
+type CPUInfo struct {
+	CPU        int         `json:"cpu"`
+	Freq       *Freq       `json:"freq,omitempty"`
+	IdleStates []IdleState `json:"idleStates,omitempty"`
+	Throttle   *Throttle   `json:"throttle,omitempty"`
+}
+
+// DeepIdleStates returns the enabled idle states whose exit latency is above `maxLatencyUs`.
+func (ci CPUInfo) DeepIdleStates(maxLatencyUs uint64) []IdleState {
+	var res []IdleState
+	for _, st := range ci.IdleStates {
+		if !st.Disabled && st.LatencyUs > maxLatencyUs {
+			res = append(res, st)
+		}
+	}
+	return res
+}
+
+type Handler struct {
+	log       *log.Logger
+	sysfsRoot string
+	fs        fswrap.FSWrapper
+}
+
+func New(logger *log.Logger, sysfsRoot string) *Handler {
+	return &Handler{
+		log:       logger,
+		sysfsRoot: sysfsRoot,
+		fs:        fswrap.FSWrapper{Log: logger},
+	}
+}
+
+// ReadInfo reads the power management state of the cpus in `cpus` the kernel knows about, sorted by cpu id.
+// Missing attributes are not critical, because they depend on the drivers and on the hardware.
+func (handler *Handler) ReadInfo(cpus cpuset.CPUSet) ([]CPUInfo, error) {
+	cpuRoot := filepath.Join(handler.sysfsRoot, "devices", "system", "cpu")
+	entries, err := handler.fs.ReadDir(cpuRoot)
+	if err != nil {
+		return nil, err
+	}
+	var infos []CPUInfo
+	for _, entry := range entries {
+		cpuid, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), "cpu"))
+		if err != nil || !strings.HasPrefix(entry.Name(), "cpu") {
+			continue // not a cpu, e.g. "cpufreq" or "cpuidle"
+		}
+		if !cpus.Contains(cpuid) {
+			continue
+		}
+		cpuDir := filepath.Join(cpuRoot, entry.Name())
+		infos = append(infos, CPUInfo{
+			CPU:        cpuid,
+			Freq:       handler.readFreq(filepath.Join(cpuDir, "cpufreq")),
+			IdleStates: handler.readIdleStates(filepath.Join(cpuDir, "cpuidle")),
+			Throttle:   handler.readThrottle(filepath.Join(cpuDir, "thermal_throttle")),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].CPU < infos[j].CPU })
+	return infos, nil
+}
+
+func (handler *Handler) readFreq(dir string) *Freq {
+	governor, err := handler.readString(filepath.Join(dir, "scaling_governor"))
+	if err != nil {
+		handler.log.Printf("Error reading the cpufreq governor: %v", err)
+		return nil
+	}
+	freq := Freq{
+		Governor: governor,
+		MinKHz:   handler.readUint(filepath.Join(dir, "scaling_min_freq")),
+		MaxKHz:   handler.readUint(filepath.Join(dir, "scaling_max_freq")),
+		CurKHz:   handler.readUint(filepath.Join(dir, "scaling_cur_freq")),
+		HWMaxKHz: handler.readUint(filepath.Join(dir, "cpuinfo_max_freq")),
+	}
+	freq.Driver, _ = handler.readString(filepath.Join(dir, "scaling_driver"))
+	freq.EnergyPerfPreference, _ = handler.readString(filepath.Join(dir, "energy_performance_preference"))
+	return &freq
+}
+
+func (handler *Handler) readIdleStates(dir string) []IdleState {
+	entries, err := handler.fs.ReadDir(dir)
+	if err != nil {
+		handler.log.Printf("Error reading the cpuidle states: %v", err)
+		return nil
+	}
+	var states []IdleState
+	for _, entry := range entries {
+		idx, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), "state"))
+		if err != nil || !strings.HasPrefix(entry.Name(), "state") {
+			continue
+		}
+		stateDir := filepath.Join(dir, entry.Name())
+		st := IdleState{
+			Index:     idx,
+			LatencyUs: handler.readUint(filepath.Join(stateDir, "latency")),
+			Disabled:  handler.readUint(filepath.Join(stateDir, "disable")) != 0,
+			Usage:     handler.readUint(filepath.Join(stateDir, "usage")),
+			TimeUs:    handler.readUint(filepath.Join(stateDir, "time")),
+		}
+		st.Name, _ = handler.readString(filepath.Join(stateDir, "name"))
+		states = append(states, st)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Index < states[j].Index })
+	return states
+}
+
+func (handler *Handler) readThrottle(dir string) *Throttle {
+	if _, err := handler.fs.ReadDir(dir); err != nil {
+		// thermal_throttle is x86 only
+		handler.log.Printf("Error reading the thermal throttle counters: %v", err)
+		return nil
+	}
+	return &Throttle{
+		CoreCount:     handler.readUint(filepath.Join(dir, "core_throttle_count")),
+		CoreTimeMs:    handler.readUint(filepath.Join(dir, "core_throttle_total_time_ms")),
+		PackageCount:  handler.readUint(filepath.Join(dir, "package_throttle_count")),
+		PackageTimeMs: handler.readUint(filepath.Join(dir, "package_throttle_total_time_ms")),
+	}
+}
+
+func (handler *Handler) readString(path string) (string, error) {
+	data, err := handler.fs.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// readUint returns zero if the attribute is missing or unparsable
+func (handler *Handler) readUint(path string) uint64 {
+	val, err := handler.readString(path)
+	if err != nil {
+		handler.log.Printf("Error reading %q: %v", path, err)
+		return 0
+	}
+	num, err := strconv.ParseUint(val, 10, 64)
+	if err != nil {
+		handler.log.Printf("Error parsing %q: %v", path, err)
+		return 0
+	}
+	return num
+}
@@ -0,0 +1,266 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package powermgmt_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/internal/fakefs"
+	"github.com/openshift-kni/debug-tools/pkg/isolation"
+	"github.com/openshift-kni/debug-tools/pkg/powermgmt"
+)
+
+var nullLog = log.New(ioutil.Discard, "", 0)
+
+type fakeIdleState struct {
+	name     string
+	latency  int
+	disabled bool
+}
+
+var fakeIdleStates = []fakeIdleState{
+	{"POLL", 0, false},
+	{"C1", 2, false},
+	{"C1E", 10, false},
+	{"C6", 133, false},
+}
+
+func TestReadInfo(t *testing.T) {
+	sysDir := makeFakeSysFS(t)
+	defer os.RemoveAll(sysDir) // clean up
+
+	infos, err := powermgmt.New(nullLog, sysDir).ReadInfo(cpuset.New(0, 1, 2, 3))
+	if err != nil {
+		t.Fatalf("ReadInfo failed: %v", err)
+	}
+	if len(infos) != 3 {
+		t.Fatalf("unexpected infos: %+v", infos)
+	}
+
+	ci := infos[1]
+	if ci.CPU != 2 {
+		t.Fatalf("unexpected cpu: %d", ci.CPU)
+	}
+	expectedFreq := powermgmt.Freq{
+		Driver:               "intel_pstate",
+		Governor:             "powersave",
+		MinKHz:               800000,
+		MaxKHz:               3500000,
+		CurKHz:               3400000,
+		HWMaxKHz:             3500000,
+		EnergyPerfPreference: "balance_performance",
+	}
+	if ci.Freq == nil || *ci.Freq != expectedFreq {
+		t.Errorf("unexpected freq: %+v", ci.Freq)
+	}
+	if len(ci.IdleStates) != len(fakeIdleStates) {
+		t.Fatalf("unexpected idle states: %+v", ci.IdleStates)
+	}
+	expectedState := powermgmt.IdleState{Index: 3, Name: "C6", LatencyUs: 133, Usage: 30, TimeUs: 3000}
+	if ci.IdleStates[3] != expectedState {
+		t.Errorf("unexpected idle state: got %+v expected %+v", ci.IdleStates[3], expectedState)
+	}
+	if ci.Throttle == nil || ci.Throttle.PackageCount != 4 {
+		t.Errorf("unexpected throttle: %+v", ci.Throttle)
+	}
+
+	// cpu 3 has no cpufreq, no cpuidle and no thermal_throttle
+	ci = infos[2]
+	if ci.Freq != nil || len(ci.IdleStates) != 0 || ci.Throttle != nil {
+		t.Errorf("unexpected info for cpu 3: %+v", ci)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	sysDir := makeFakeSysFS(t)
+	defer os.RemoveAll(sysDir) // clean up
+
+	infos, err := powermgmt.New(nullLog, sysDir).ReadInfo(cpuset.New(0, 1, 2, 3))
+	if err != nil {
+		t.Fatalf("ReadInfo failed: %v", err)
+	}
+
+	var testCases = []struct {
+		name       string
+		isolated   cpuset.CPUSet
+		maxLatency uint64
+		expected   map[string][]int
+	}{
+		{
+			name:       "no isolated cpus",
+			isolated:   cpuset.New(),
+			maxLatency: 10,
+			expected: map[string][]int{
+				"no-isolated-cpus": nil,
+			},
+		},
+		{
+			name:       "tuned cpu",
+			isolated:   cpuset.New(1),
+			maxLatency: 10,
+			expected:   map[string][]int{},
+		},
+		{
+			name:       "untuned cpus",
+			isolated:   cpuset.New(2, 3),
+			maxLatency: 10,
+			expected: map[string][]int{
+				"cpufreq-missing":          {3},
+				"governor-not-performance": {2},
+				"epp-not-performance":      {2},
+				"frequency-not-pinned":     {2},
+				"cpuidle-missing":          {3},
+				"deep-idle-states":         {2},
+				"thermal-throttled":        {2},
+			},
+		},
+		{
+			name:       "relaxed latency",
+			isolated:   cpuset.New(1, 2),
+			maxLatency: 200,
+			expected: map[string][]int{
+				"governor-not-performance": {2},
+				"epp-not-performance":      {2},
+				"frequency-not-pinned":     {2},
+				"thermal-throttled":        {2},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findingsByCode(powermgmt.Check(infos, tt.isolated, tt.maxLatency))
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("unexpected findings\ngot=%v\nexpected=%v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDelta(t *testing.T) {
+	prev := []powermgmt.CPUInfo{
+		{
+			CPU: 2,
+			IdleStates: []powermgmt.IdleState{
+				{Index: 0, Name: "POLL", Usage: 10, TimeUs: 1000},
+				{Index: 1, Name: "C1", Usage: 10, TimeUs: 1000},
+			},
+			Throttle: &powermgmt.Throttle{CoreCount: 1, CoreTimeMs: 5},
+		},
+		{
+			CPU: 3,
+		},
+	}
+	last := []powermgmt.CPUInfo{
+		{
+			CPU: 2,
+			IdleStates: []powermgmt.IdleState{
+				{Index: 0, Name: "POLL", Usage: 10, TimeUs: 1000},
+				{Index: 1, Name: "C1", Usage: 15, TimeUs: 251000},
+			},
+			Throttle: &powermgmt.Throttle{CoreCount: 2, CoreTimeMs: 7, PackageCount: 1, PackageTimeMs: 3},
+		},
+		{
+			// went offline meantime
+			CPU: 4,
+		},
+	}
+
+	got := powermgmt.Delta(prev, last, time.Second)
+	expected := []powermgmt.CPUDelta{
+		{
+			CPU: 2,
+			IdleStates: []powermgmt.IdleStateDelta{
+				{Name: "POLL"},
+				{Name: "C1", Usage: 5, TimeUs: 250000, Residency: 25},
+			},
+			ThrottleEvents: 2,
+			ThrottleTimeMs: 5,
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected delta\ngot=%+v\nexpected=%+v", got, expected)
+	}
+}
+
+func findingsByCode(findings []isolation.Finding) map[string][]int {
+	res := make(map[string][]int)
+	for _, fi := range findings {
+		res[fi.Code] = fi.CPUs
+	}
+	return res
+}
+
+// makeFakeSysFS creates cpu 1, tuned for low latency, cpu 2, not tuned, and cpu 3, without power management.
+func makeFakeSysFS(t *testing.T) string {
+	t.Helper()
+	rootDir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	cpuRoot := filepath.Join(rootDir, "devices", "system", "cpu")
+	fakefs.WriteFile(t, filepath.Join(cpuRoot, "online"), "1-3\n")
+	fakefs.WriteFile(t, filepath.Join(cpuRoot, "cpufreq", "boost"), "1\n")
+	fakefs.WriteFile(t, filepath.Join(cpuRoot, "cpu3", "online"), "1\n")
+
+	for _, cpuid := range []int{1, 2} {
+		cpuDir := filepath.Join(cpuRoot, fmt.Sprintf("cpu%d", cpuid))
+		governor, epp, minFreq := "performance", "performance", "3500000"
+		if cpuid == 2 {
+			governor, epp, minFreq = "powersave", "balance_performance", "800000"
+		}
+		fakefs.WriteFile(t, filepath.Join(cpuDir, "cpufreq", "scaling_driver"), "intel_pstate\n")
+		fakefs.WriteFile(t, filepath.Join(cpuDir, "cpufreq", "scaling_governor"), governor+"\n")
+		fakefs.WriteFile(t, filepath.Join(cpuDir, "cpufreq", "scaling_min_freq"), minFreq+"\n")
+		fakefs.WriteFile(t, filepath.Join(cpuDir, "cpufreq", "scaling_max_freq"), "3500000\n")
+		fakefs.WriteFile(t, filepath.Join(cpuDir, "cpufreq", "scaling_cur_freq"), "3400000\n")
+		fakefs.WriteFile(t, filepath.Join(cpuDir, "cpufreq", "cpuinfo_max_freq"), "3500000\n")
+		fakefs.WriteFile(t, filepath.Join(cpuDir, "cpufreq", "energy_performance_preference"), epp+"\n")
+
+		for idx, st := range fakeIdleStates {
+			stateDir := filepath.Join(cpuDir, "cpuidle", fmt.Sprintf("state%d", idx))
+			disabled := "0"
+			if cpuid == 1 && st.latency > 10 {
+				disabled = "1"
+			}
+			fakefs.WriteFile(t, filepath.Join(stateDir, "name"), st.name+"\n")
+			fakefs.WriteFile(t, filepath.Join(stateDir, "latency"), fmt.Sprintf("%d\n", st.latency))
+			fakefs.WriteFile(t, filepath.Join(stateDir, "disable"), disabled+"\n")
+			fakefs.WriteFile(t, filepath.Join(stateDir, "usage"), fmt.Sprintf("%d\n", idx*10))
+			fakefs.WriteFile(t, filepath.Join(stateDir, "time"), fmt.Sprintf("%d\n", idx*1000))
+		}
+
+		throttleCount := "0"
+		if cpuid == 2 {
+			throttleCount = "4"
+		}
+		fakefs.WriteFile(t, filepath.Join(cpuDir, "thermal_throttle", "core_throttle_count"), "0\n")
+		fakefs.WriteFile(t, filepath.Join(cpuDir, "thermal_throttle", "core_throttle_total_time_ms"), "0\n")
+		fakefs.WriteFile(t, filepath.Join(cpuDir, "thermal_throttle", "package_throttle_count"), throttleCount+"\n")
+		fakefs.WriteFile(t, filepath.Join(cpuDir, "thermal_throttle", "package_throttle_total_time_ms"), "12\n")
+	}
+	return rootDir
+}
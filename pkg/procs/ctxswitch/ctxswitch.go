@@ -0,0 +1,112 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package ctxswitch
+
+import (
+	"sort"
+	"time"
+
+	"github.com/openshift-kni/debug-tools/pkg/counter"
+	"github.com/openshift-kni/debug-tools/pkg/procs"
+)
+
+// ThreadSample holds the context switch counters and the CPU time of a thread at a given time
+type ThreadSample struct {
+	TID          int
+	Name         string
+	Voluntary    uint64
+	Nonvoluntary uint64
+	// RunTime is the time spent on the CPU, in nanoseconds
+	RunTime uint64
+}
+
+type Sample struct {
+	Timestamp time.Time
+	// TID -> sample
+	Threads map[int]ThreadSample
+}
+
+// NewSample merges the context switch counters from `pidInfo` with the CPU time from `tasks`.
+// Threads missing from either source are skipped.
+func NewSample(ts time.Time, pidInfo procs.PIDInfo, tasks procs.TaskSamples) Sample {
+	sample := Sample{
+		Timestamp: ts,
+		Threads:   make(map[int]ThreadSample),
+	}
+	for tid, tidInfo := range pidInfo.TIDs {
+		task, ok := tasks[tid]
+		if !ok {
+			continue
+		}
+		sample.Threads[tid] = ThreadSample{
+			TID:          tid,
+			Name:         tidInfo.Name,
+			Voluntary:    tidInfo.VoluntaryCtxtSwitches,
+			Nonvoluntary: tidInfo.NonvoluntaryCtxtSwitches,
+			RunTime:      task.RunTime,
+		}
+	}
+	return sample
+}
+
+type ThreadDelta struct {
+	TID              int     `json:"tid"`
+	Name             string  `json:"name"`
+	Voluntary        uint64  `json:"voluntary"`
+	Nonvoluntary     uint64  `json:"nonvoluntary"`
+	VoluntaryRate    float64 `json:"voluntaryRate"`
+	NonvoluntaryRate float64 `json:"nonvoluntaryRate"`
+	// CPUUsage is the percentage of the elapsed time the thread spent running
+	CPUUsage float64 `json:"cpuUsage"`
+	// Busy is true if the thread looks like busy polling, consuming at least the threshold CPU time
+	Busy bool `json:"busy"`
+	// Preempted is true if the thread is busy and got involuntarily context-switched
+	Preempted bool `json:"preempted"`
+}
+
+// Compute returns the per-thread deltas between the `prev` and `last` samples, sorted by TID.
+// Threads using at least `busyThreshold` percent of the elapsed time are considered busy pollers,
+// and any involuntary context switch they suffered is flagged.
+func Compute(prev, last Sample, busyThreshold float64) []ThreadDelta {
+	elapsed := last.Timestamp.Sub(prev.Timestamp)
+	var res []ThreadDelta
+	for tid, lastThread := range last.Threads {
+		prevThread, ok := prev.Threads[tid]
+		if !ok {
+			// we can't tell anything meaningful about new threads
+			continue
+		}
+		td := ThreadDelta{
+			TID:          tid,
+			Name:         lastThread.Name,
+			Voluntary:    counter.Delta(prevThread.Voluntary, lastThread.Voluntary),
+			Nonvoluntary: counter.Delta(prevThread.Nonvoluntary, lastThread.Nonvoluntary),
+		}
+		if elapsed > 0 {
+			td.VoluntaryRate = float64(td.Voluntary) / elapsed.Seconds()
+			td.NonvoluntaryRate = float64(td.Nonvoluntary) / elapsed.Seconds()
+			td.CPUUsage = 100.0 * float64(counter.Delta(prevThread.RunTime, lastThread.RunTime)) / float64(elapsed.Nanoseconds())
+		}
+		td.Busy = td.CPUUsage >= busyThreshold
+		td.Preempted = td.Busy && td.Nonvoluntary > 0
+		res = append(res, td)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].TID < res[j].TID
+	})
+	return res
+}
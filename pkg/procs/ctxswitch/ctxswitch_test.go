@@ -0,0 +1,83 @@
+package ctxswitch_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/debug-tools/pkg/procs"
+	"github.com/openshift-kni/debug-tools/pkg/procs/ctxswitch"
+)
+
+func TestNewSample(t *testing.T) {
+	ts := time.Now()
+	pidInfo := procs.PIDInfo{
+		Pid: 42,
+		TIDs: map[int]procs.TIDInfo{
+			42: {Tid: 42, Name: "testpmd", VoluntaryCtxtSwitches: 10, NonvoluntaryCtxtSwitches: 1},
+			43: {Tid: 43, Name: "lcore-worker-2", VoluntaryCtxtSwitches: 0, NonvoluntaryCtxtSwitches: 3},
+			// gone meantime
+			44: {Tid: 44, Name: "rte_mp_handle"},
+		},
+	}
+	tasks := procs.TaskSamples{
+		42: {PID: 42, TID: 42, RunTime: 1000},
+		43: {PID: 42, TID: 43, RunTime: 2000},
+	}
+
+	sample := ctxswitch.NewSample(ts, pidInfo, tasks)
+	if len(sample.Threads) != 2 {
+		t.Fatalf("unexpected threads: %v", sample.Threads)
+	}
+	got := sample.Threads[43]
+	if got.Name != "lcore-worker-2" || got.Nonvoluntary != 3 || got.RunTime != 2000 {
+		t.Errorf("unexpected sample: %+v", got)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	initTs := time.Now()
+	prev := ctxswitch.Sample{
+		Timestamp: initTs,
+		Threads: map[int]ctxswitch.ThreadSample{
+			42: {TID: 42, Name: "testpmd", Voluntary: 10, Nonvoluntary: 1, RunTime: 0},
+			43: {TID: 43, Name: "lcore-worker-2", Voluntary: 0, Nonvoluntary: 3, RunTime: 0},
+			44: {TID: 44, Name: "lcore-worker-3", Voluntary: 0, Nonvoluntary: 0, RunTime: 0},
+		},
+	}
+	last := ctxswitch.Sample{
+		Timestamp: initTs.Add(2 * time.Second),
+		Threads: map[int]ctxswitch.ThreadSample{
+			42: {TID: 42, Name: "testpmd", Voluntary: 30, Nonvoluntary: 2, RunTime: uint64(10 * time.Millisecond)},
+			43: {TID: 43, Name: "lcore-worker-2", Voluntary: 0, Nonvoluntary: 5, RunTime: uint64(1990 * time.Millisecond)},
+			44: {TID: 44, Name: "lcore-worker-3", Voluntary: 0, Nonvoluntary: 0, RunTime: uint64(2 * time.Second)},
+			// new thread, skipped
+			45: {TID: 45, Name: "eal-intr-thread", Voluntary: 1, Nonvoluntary: 0, RunTime: 0},
+		},
+	}
+
+	deltas := ctxswitch.Compute(prev, last, 90.0)
+	if len(deltas) != 3 {
+		t.Fatalf("unexpected deltas: %v", deltas)
+	}
+
+	expected := []struct {
+		tid          int
+		voluntary    uint64
+		nonvoluntary uint64
+		busy         bool
+		preempted    bool
+	}{
+		{42, 20, 1, false, false},
+		{43, 0, 2, true, true},
+		{44, 0, 0, true, false},
+	}
+	for idx, exp := range expected {
+		td := deltas[idx]
+		if td.TID != exp.tid || td.Voluntary != exp.voluntary || td.Nonvoluntary != exp.nonvoluntary || td.Busy != exp.busy || td.Preempted != exp.preempted {
+			t.Errorf("unexpected delta for tid %d: %+v", exp.tid, td)
+		}
+	}
+	if rate := deltas[0].VoluntaryRate; rate != 10.0 {
+		t.Errorf("unexpected voluntary rate: %v", rate)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package ctxswitch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+type Reporter interface {
+	Delta(ts time.Time, prev, last Sample)
+	Summary(initTs time.Time, prev, last Sample)
+}
+
+func NewReporter(sink io.Writer, jsonOutput bool, verbose int, busyThreshold float64) Reporter {
+	if jsonOutput {
+		return &reporterJSON{
+			verbose:       verbose,
+			busyThreshold: busyThreshold,
+			sink:          sink,
+		}
+	}
+	return &reporterText{
+		verbose:       verbose,
+		busyThreshold: busyThreshold,
+		sink:          sink,
+	}
+}
+
+type reporterText struct {
+	verbose       int
+	busyThreshold float64
+	sink          io.Writer
+}
+
+func (rt *reporterText) Delta(ts time.Time, prev, last Sample) {
+	if rt.verbose < 1 {
+		return
+	}
+	for _, td := range Compute(prev, last, rt.busyThreshold) {
+		// preemptions are worth reporting as soon as they happen
+		if td.Preempted {
+			fmt.Fprintf(rt.sink, "%v TID=%d (%s) PREEMPTED busy=%.1f%% nonvoluntary=+%d\n", ts, td.TID, td.Name, td.CPUUsage, td.Nonvoluntary)
+			continue
+		}
+		if rt.verbose < 2 || (td.Voluntary == 0 && td.Nonvoluntary == 0) {
+			continue
+		}
+		fmt.Fprintf(rt.sink, "%v TID=%d (%s) voluntary=+%d nonvoluntary=+%d\n", ts, td.TID, td.Name, td.Voluntary, td.Nonvoluntary)
+	}
+}
+
+func (rt *reporterText) Summary(initTs time.Time, prev, last Sample) {
+	if rt.verbose < 1 {
+		return
+	}
+	timeDelta := time.Now().Sub(initTs)
+
+	fmt.Fprintf(rt.sink, "\ncontext switch summary after %v\n", timeDelta)
+	for _, td := range Compute(prev, last, rt.busyThreshold) {
+		flag := ""
+		if td.Preempted {
+			flag = " PREEMPTED"
+		} else if td.Busy {
+			flag = " BUSY"
+		}
+		fmt.Fprintf(rt.sink, "TID=%d (%s) cpu=%.1f%% voluntary=+%d (%.2f/s) nonvoluntary=+%d (%.2f/s)%s\n",
+			td.TID, td.Name, td.CPUUsage, td.Voluntary, td.VoluntaryRate, td.Nonvoluntary, td.NonvoluntaryRate, flag)
+	}
+}
+
+type reporterJSON struct {
+	verbose       int
+	busyThreshold float64
+	sink          io.Writer
+}
+
+type ctxswitchDelta struct {
+	Timestamp time.Time     `json:"timestamp"`
+	Threads   []ThreadDelta `json:"threads"`
+}
+
+func (rj *reporterJSON) Delta(ts time.Time, prev, last Sample) {
+	if rj.verbose < 1 {
+		return
+	}
+	deltas := Compute(prev, last, rj.busyThreshold)
+	if rj.verbose < 2 {
+		// preemptions are worth reporting as soon as they happen
+		var preempted []ThreadDelta
+		for _, td := range deltas {
+			if td.Preempted {
+				preempted = append(preempted, td)
+			}
+		}
+		if len(preempted) == 0 {
+			return
+		}
+		deltas = preempted
+	}
+	res := ctxswitchDelta{
+		Timestamp: ts,
+		Threads:   deltas,
+	}
+	json.NewEncoder(rj.sink).Encode(res)
+}
+
+type ctxswitchSummary struct {
+	Elapsed time.Duration `json:"elapsed"`
+	Threads []ThreadDelta `json:"threads"`
+}
+
+func (rj *reporterJSON) Summary(initTs time.Time, prev, last Sample) {
+	if rj.verbose < 1 {
+		return
+	}
+	res := ctxswitchSummary{
+		Elapsed: time.Now().Sub(initTs),
+		Threads: Compute(prev, last, rj.busyThreshold),
+	}
+	json.NewEncoder(rj.sink).Encode(res)
+}
@@ -26,6 +26,7 @@ import (
 
 	cpuset "k8s.io/utils/cpuset"
 
+	"github.com/openshift-kni/debug-tools/pkg/cgroups"
 	"github.com/openshift-kni/debug-tools/pkg/fswrap"
 )
 
@@ -33,12 +34,28 @@ type TIDInfo struct {
 	Tid      int    `json:"tid"`
 	Name     string `json:"name"`
 	Affinity []int  `json:"affinity"`
+	// State is the single-letter state as reported in /proc/<pid>/stat (R, S, D...)
+	State      string `json:"state,omitempty"`
+	Policy     string `json:"policy,omitempty"`
+	RTPriority int    `json:"rtPriority"`
+	Nice       int    `json:"nice"`
+	// context switch counters, see man 5 proc
+	VoluntaryCtxtSwitches    uint64 `json:"voluntaryCtxtSwitches"`
+	NonvoluntaryCtxtSwitches uint64 `json:"nonvoluntaryCtxtSwitches"`
+	// MemsAllowed are the NUMA nodes the thread is allowed to allocate memory on
+	MemsAllowed []int `json:"memsAllowed,omitempty"`
 }
 
 type PIDInfo struct {
 	Pid  int             `json:"pid"`
+	PPid int             `json:"ppid,omitempty"`
 	Name string          `json:"name"`
 	TIDs map[int]TIDInfo `json:"threads"`
+	// KThread is set only for kernel threads
+	KThread *KThreadInfo `json:"kthread,omitempty"`
+	// Cgroup is the path of the process in the cgroup hierarchy, as seen in /proc/<pid>/cgroup
+	Cgroup string `json:"cgroup,omitempty"`
+	cgroups.KubeInfo
 }
 
 type Handler struct {
@@ -90,6 +107,7 @@ func (handler *Handler) FromPID(pid int) (PIDInfo, error) {
 	}
 
 	procName, err := handler.readProcessName(pid)
+	cmdlineEmpty := err == nil && procName == ""
 	if err == nil {
 		pidInfo.Name = fixFilename(procName)
 	} else {
@@ -97,6 +115,15 @@ func (handler *Handler) FromPID(pid int) (PIDInfo, error) {
 		handler.log.Printf("Error reading process name for pid %d: %v", pid, err)
 	}
 
+	cgroupPath, err := handler.readCgroup(pid)
+	if err == nil {
+		pidInfo.Cgroup = cgroupPath
+		pidInfo.KubeInfo = cgroups.ParseKubepodsPath(cgroupPath)
+	} else {
+		// failures are not critical
+		handler.log.Printf("Error reading cgroup for pid %d: %v", pid, err)
+	}
+
 	tasksDir := filepath.Join(handler.procfsRoot, procEntry(pid), "task")
 	tidEntries, err := handler.fs.ReadDir(tasksDir)
 	if err != nil {
@@ -105,16 +132,19 @@ func (handler *Handler) FromPID(pid int) (PIDInfo, error) {
 	}
 
 	for _, tidEntry := range tidEntries {
+		taskDir := filepath.Join(tasksDir, tidEntry.Name())
 		// TODO: use x/sys/unix schedGetAffinity?
-		info, err := handler.parseProcStatus(filepath.Join(tasksDir, tidEntry.Name(), "status"))
+		info, err := handler.parseProcStatus(filepath.Join(taskDir, "status"))
 		if err != nil {
 			// failures are not critical
 			handler.log.Printf("Error parsing status for pid %d tid %s: %v", pid, tidEntry.Name(), err)
 			continue
 		}
+		handler.fillSchedInfo(&info, taskDir)
 		pidInfo.TIDs[info.Tid] = info
 	}
 
+	handler.fillKThreadInfo(&pidInfo, cmdlineEmpty)
 	return pidInfo, nil
 }
 
@@ -151,11 +181,61 @@ func (handler *Handler) parseProcStatus(path string) (TIDInfo, error) {
 			}
 			info.Affinity = cpuIDs.List()
 		}
+		if strings.HasPrefix(line, "Mems_allowed_list:") {
+			items := strings.SplitN(line, ":", 2)
+			nodeIDs, err := cpuset.Parse(strings.TrimSpace(items[1]))
+			if err != nil {
+				return info, err
+			}
+			info.MemsAllowed = nodeIDs.List()
+		}
+		if strings.HasPrefix(line, "voluntary_ctxt_switches:") {
+			items := strings.SplitN(line, ":", 2)
+			val, err := strconv.ParseUint(strings.TrimSpace(items[1]), 10, 64)
+			if err != nil {
+				return info, err
+			}
+			info.VoluntaryCtxtSwitches = val
+		}
+		if strings.HasPrefix(line, "nonvoluntary_ctxt_switches:") {
+			items := strings.SplitN(line, ":", 2)
+			val, err := strconv.ParseUint(strings.TrimSpace(items[1]), 10, 64)
+			if err != nil {
+				return info, err
+			}
+			info.NonvoluntaryCtxtSwitches = val
+		}
 	}
 
 	return info, scanner.Err()
 }
 
+func (handler *Handler) fillSchedInfo(info *TIDInfo, taskDir string) {
+	st, err := handler.readStat(filepath.Join(taskDir, "stat"))
+	if err != nil {
+		// failures are not critical
+		handler.log.Printf("Error parsing stat for tid %d: %v", info.Tid, err)
+		st, err = handler.readSched(filepath.Join(taskDir, "sched"))
+		if err != nil {
+			handler.log.Printf("Error parsing sched for tid %d: %v", info.Tid, err)
+			return
+		}
+	}
+	info.State = st.state
+	info.Policy = PolicyName(st.policy)
+	info.RTPriority = st.rtPriority
+	info.Nice = st.nice
+}
+
+func (handler *Handler) readCgroup(pid int) (string, error) {
+	file, err := handler.fs.Open(filepath.Join(handler.procfsRoot, procEntry(pid), "cgroup"))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	return cgroups.ParseProcCgroup(file)
+}
+
 func (handler *Handler) readProcessName(pid int) (string, error) {
 	data, err := handler.fs.ReadFile(filepath.Join(handler.procfsRoot, procEntry(pid), "cmdline"))
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/openshift-kni/debug-tools/pkg/procs"
 )
@@ -79,6 +80,361 @@ func TestSingleProcSingleThread(t *testing.T) {
 	}
 }
 
+func TestSchedInfo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(dir) // clean up
+
+	if err := makeFakeTree(dir, map[int]fakeEntry{
+		42: fakeEntry{
+			attrs: fakeAttrs{
+				"cmdline": "/usr/bin/testpmd\x00-l\x002-3",
+			},
+			tasks: map[int]fakeAttrs{
+				42: fakeAttrs{
+					"status": "Name:	testpmd\nPid:	42\nCpus_allowed_list:	0-1\n",
+					"stat":   fakeStat(42, "testpmd", "S", -5, 0, 0),
+				},
+				43: fakeAttrs{
+					"status": "Name:	lcore-worker-2\nPid:	43\nCpus_allowed_list:	2\nvoluntary_ctxt_switches:	12\nnonvoluntary_ctxt_switches:	3\n",
+					"stat":   fakeStat(43, "lcore-worker-2 (x)", "R", 0, 95, 1),
+				},
+				44: fakeAttrs{
+					// stat intentionally missing, we should fall back to sched
+					"status": "Name:	rte_mp_handle\nPid:	44\nCpus_allowed_list:	0-1\n",
+					"sched":  "rte_mp_handle (44, #threads: 3)\n-------------------\nse.exec_start                                :      1234.5\npolicy                                       :                    2\nprio                                         :                   89\n",
+				},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("populating temp dir %v", err)
+	}
+
+	ph := procs.New(nullLog, dir)
+	pidInfo, err := ph.FromPID(42)
+	if err != nil {
+		t.Fatalf("FromPID(42) failed: %v", err)
+	}
+
+	expected := map[int]procs.TIDInfo{
+		42: procs.TIDInfo{
+			Tid:        42,
+			Name:       "testpmd",
+			Affinity:   []int{0, 1},
+			State:      "S",
+			Policy:     procs.PolicyOther,
+			RTPriority: 0,
+			Nice:       -5,
+		},
+		43: procs.TIDInfo{
+			Tid:        43,
+			Name:       "lcore-worker-2",
+			Affinity:   []int{2},
+			State:      "R",
+			Policy:     procs.PolicyFIFO,
+			RTPriority: 95,
+			Nice:       0,
+
+			VoluntaryCtxtSwitches:    12,
+			NonvoluntaryCtxtSwitches: 3,
+		},
+		44: procs.TIDInfo{
+			Tid:        44,
+			Name:       "rte_mp_handle",
+			Affinity:   []int{0, 1},
+			Policy:     procs.PolicyRR,
+			RTPriority: 10,
+			Nice:       0,
+		},
+	}
+	if !reflect.DeepEqual(pidInfo.TIDs, expected) {
+		t.Errorf("unexpected return value: got=%#v expected=%#v", pidInfo.TIDs, expected)
+	}
+}
+
+func TestCgroupAttribution(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(dir) // clean up
+
+	if err := makeFakeTree(dir, map[int]fakeEntry{
+		42: fakeEntry{
+			attrs: fakeAttrs{
+				"cmdline": "/usr/bin/testpmd",
+				"cgroup":  "0::/kubepods.slice/kubepods-pod6f7b6ffa_2d4f.slice/crio-0123456789abcdef.scope\n",
+			},
+			tasks: map[int]fakeAttrs{
+				42: fakeAttrs{
+					"status": "Name:	testpmd\nPid:	42\nCpus_allowed_list:	2-3\n",
+				},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("populating temp dir %v", err)
+	}
+
+	ph := procs.New(nullLog, dir)
+	pidInfo, err := ph.FromPID(42)
+	if err != nil {
+		t.Fatalf("FromPID(42) failed: %v", err)
+	}
+	if pidInfo.Cgroup != "/kubepods.slice/kubepods-pod6f7b6ffa_2d4f.slice/crio-0123456789abcdef.scope" {
+		t.Errorf("unexpected cgroup: %q", pidInfo.Cgroup)
+	}
+	if pidInfo.PodUID != "6f7b6ffa-2d4f" || pidInfo.ContainerID != "0123456789abcdef" || pidInfo.QOSClass != "Guaranteed" {
+		t.Errorf("unexpected kube info: %+v", pidInfo.KubeInfo)
+	}
+}
+
+func TestNUMAPlacement(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(dir) // clean up
+
+	numaMaps := `55d0c8a00000 default file=/usr/bin/testpmd mapped=10 N0=10 kernelpagesize_kB=4
+55d0c9e00000 default heap anon=300 dirty=300 N0=200 N1=100 kernelpagesize_kB=4
+7f1b2c000000 default file=/dev/hugepages/rtemap_0 huge dirty=1 N1=1 kernelpagesize_kB=1048576
+7f1b6c000000 default anon=2 dirty=2 N0=2 kernelpagesize_kB=4
+7ffd1a200000 default stack anon=8 dirty=8 N0=8 kernelpagesize_kB=4
+7ffd1a3f0000 default
+`
+	if err := makeFakeTree(dir, map[int]fakeEntry{
+		42: fakeEntry{
+			attrs: fakeAttrs{
+				"cmdline":   "/usr/bin/testpmd",
+				"numa_maps": numaMaps,
+			},
+			tasks: map[int]fakeAttrs{
+				42: fakeAttrs{
+					"status": "Name:	testpmd\nPid:	42\nCpus_allowed_list:	2-3\nMems_allowed_list:	0-1\n",
+				},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("populating temp dir %v", err)
+	}
+
+	ph := procs.New(nullLog, dir)
+	pidInfo, err := ph.FromPID(42)
+	if err != nil {
+		t.Fatalf("FromPID(42) failed: %v", err)
+	}
+	if got := pidInfo.TIDs[42].MemsAllowed; !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Errorf("unexpected mems allowed: %v", got)
+	}
+
+	nm, err := ph.ReadNUMAMaps(42)
+	if err != nil {
+		t.Fatalf("ReadNUMAMaps(42) failed: %v", err)
+	}
+	expected := procs.NUMAMaps{
+		0: procs.NUMAMemory{
+			Anon: 210 * 4096,
+			File: 10 * 4096,
+		},
+		1: procs.NUMAMemory{
+			Anon: 100 * 4096,
+			Huge: 1 << 30,
+		},
+	}
+	if !reflect.DeepEqual(nm, expected) {
+		t.Errorf("unexpected numa maps: got=%#v expected=%#v", nm, expected)
+	}
+	if nodes := nm.Nodes(); !reflect.DeepEqual(nodes, []int{0, 1}) {
+		t.Errorf("unexpected nodes: %v", nodes)
+	}
+}
+
+func TestKThreads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(dir) // clean up
+
+	if err := makeFakeTree(dir, map[int]fakeEntry{
+		1: fakeEntry{
+			attrs: fakeAttrs{
+				"cmdline": "/usr/lib/systemd/systemd\x00--switched-root",
+				"stat":    fakeStat(1, "systemd", "S", 0, 0, 0),
+			},
+			tasks: map[int]fakeAttrs{
+				1: fakeAttrs{
+					"status": "Name:	systemd\nPid:	1\nCpus_allowed_list:	0-3\n",
+				},
+			},
+		},
+		30: fakeEntry{
+			attrs: fakeAttrs{
+				"cmdline": "",
+				"stat":    fakeKThreadStat(30, "ksoftirqd/3", 0x00208040),
+			},
+			tasks: map[int]fakeAttrs{
+				30: fakeAttrs{
+					"status": "Name:	ksoftirqd/3\nPid:	30\nCpus_allowed_list:	3\n",
+				},
+			},
+		},
+		// flags not reporting PF_KTHREAD, detected by the kthreadd parent
+		31: fakeEntry{
+			attrs: fakeAttrs{
+				"cmdline": "",
+				"stat":    fakeKThreadStat(31, "rcuop/3", 0),
+			},
+			tasks: map[int]fakeAttrs{
+				31: fakeAttrs{
+					"status": "Name:	rcuop/3\nPid:	31\nCpus_allowed_list:	0-1\n",
+				},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("populating temp dir %v", err)
+	}
+
+	ph := procs.New(nullLog, dir)
+	pidInfos, err := ph.ListAll()
+	if err != nil {
+		t.Fatalf("ListAll(%s) failed: %v", dir, err)
+	}
+
+	if got := pidInfos[1].KThread; got != nil {
+		t.Errorf("unexpected kthread info for pid 1: %+v", got)
+	}
+	expected := map[int]procs.KThreadInfo{
+		30: procs.KThreadInfo{Kind: procs.KThreadPerCPU, CPU: 3, IRQ: -1},
+		31: procs.KThreadInfo{Kind: procs.KThreadRCUOffload, CPU: 3, IRQ: -1},
+	}
+	for pid, exp := range expected {
+		got := pidInfos[pid].KThread
+		if got == nil || *got != exp {
+			t.Errorf("unexpected kthread info for pid %d: got=%+v expected=%+v", pid, got, exp)
+		}
+		if ppid := pidInfos[pid].PPid; ppid != 2 {
+			t.Errorf("unexpected ppid for pid %d: %d", pid, ppid)
+		}
+	}
+}
+
+func TestClassifyKThread(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected procs.KThreadInfo
+	}{
+		{"ksoftirqd/3", procs.KThreadInfo{Kind: procs.KThreadPerCPU, CPU: 3, IRQ: -1}},
+		{"migration/12", procs.KThreadInfo{Kind: procs.KThreadPerCPU, CPU: 12, IRQ: -1}},
+		{"cpuhp/0", procs.KThreadInfo{Kind: procs.KThreadPerCPU, CPU: 0, IRQ: -1}},
+		{"rcuc/5", procs.KThreadInfo{Kind: procs.KThreadPerCPU, CPU: 5, IRQ: -1}},
+		{"kworker/3:1-mm_percpu_wq", procs.KThreadInfo{Kind: procs.KThreadPerCPU, CPU: 3, IRQ: -1}},
+		{"kworker/3:1H", procs.KThreadInfo{Kind: procs.KThreadPerCPU, CPU: 3, IRQ: -1}},
+		{"kworker/u8:2-events_unbound", procs.KThreadInfo{Kind: procs.KThreadKworker, CPU: -1, IRQ: -1}},
+		{"kworker/R-rcu_gp", procs.KThreadInfo{Kind: procs.KThreadKworker, CPU: -1, IRQ: -1}},
+		{"rcuop/7", procs.KThreadInfo{Kind: procs.KThreadRCUOffload, CPU: 7, IRQ: -1}},
+		{"rcuog/4", procs.KThreadInfo{Kind: procs.KThreadRCUOffload, CPU: 4, IRQ: -1}},
+		{"irq/42-eth0-TxRx-0", procs.KThreadInfo{Kind: procs.KThreadIRQ, CPU: -1, IRQ: 42}},
+		{"kswapd0", procs.KThreadInfo{Kind: procs.KThreadOther, CPU: -1, IRQ: -1}},
+		{"rcu_preempt", procs.KThreadInfo{Kind: procs.KThreadOther, CPU: -1, IRQ: -1}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := procs.ClassifyKThread(tc.name)
+			if got != tc.expected {
+				t.Errorf("got=%+v expected=%+v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSampleAll(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(dir) // clean up
+
+	if err := makeFakeTree(dir, map[int]fakeEntry{
+		42: fakeEntry{
+			attrs: fakeAttrs{
+				"cmdline": "/usr/bin/testpmd",
+			},
+			tasks: map[int]fakeAttrs{
+				42: fakeAttrs{
+					"stat":      fakeStat(42, "testpmd", "S", 0, 0, 0),
+					"schedstat": "123456789 1000 42\n",
+				},
+				43: fakeAttrs{
+					// schedstat intentionally missing, we should fall back to utime+stime
+					"stat": fakeStat(43, "lcore-worker-2", "R", 0, 95, 1),
+				},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("populating temp dir %v", err)
+	}
+
+	ph := procs.New(nullLog, dir)
+	samples, err := ph.SampleAll()
+	if err != nil {
+		t.Fatalf("SampleAll failed: %v", err)
+	}
+
+	expected := procs.TaskSamples{
+		42: procs.TaskSample{
+			PID:         42,
+			TID:         42,
+			ProcessName: "testpmd",
+			ThreadName:  "testpmd",
+			Processor:   2,
+			UTime:       100,
+			STime:       20,
+			RunTime:     123456789,
+		},
+		43: procs.TaskSample{
+			PID:         42,
+			TID:         43,
+			ProcessName: "testpmd",
+			ThreadName:  "lcore-worker-2",
+			Processor:   2,
+			UTime:       100,
+			STime:       20,
+			RunTime:     uint64(1200 * time.Millisecond),
+		},
+	}
+	if !reflect.DeepEqual(samples, expected) {
+		t.Errorf("unexpected return value: got=%#v expected=%#v", samples, expected)
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	for _, name := range []string{"fifo", "FIFO", "sched_fifo", " Fifo "} {
+		policy, err := procs.ParsePolicy(name)
+		if err != nil || policy != procs.PolicyFIFO {
+			t.Errorf("ParsePolicy(%q) = %q, %v", name, policy, err)
+		}
+	}
+	if _, err := procs.ParsePolicy("bogus"); err == nil {
+		t.Errorf("ParsePolicy accepted an unknown policy")
+	}
+}
+
+// fakeStat renders a /proc/<pid>/task/<tid>/stat line with the given scheduling fields.
+// The other fields are set to plausible but meaningless values.
+func fakeStat(tid int, comm, state string, nice, rtPriority, policy int) string {
+	return fmt.Sprintf("%d (%s) %s 1 %d %d 0 -1 4194560 1234 0 0 0 100 20 0 0 20 %d 3 0 5678 1000000 200 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 2 %d %d 0 0 0 0 0 0 0 0 0 0 0\n",
+		tid, comm, state, tid, tid, nice, rtPriority, policy)
+}
+
+// fakeKThreadStat renders a /proc/<pid>/stat line of a kernel thread, child of kthreadd, with the given flags.
+func fakeKThreadStat(pid int, comm string, flags uint64) string {
+	return fmt.Sprintf("%d (%s) S 2 0 0 0 -1 %d 0 0 0 0 0 0 0 0 20 0 1 0 5 0 0 18446744073709551615 0 0 0 0 0 0 0 2147483647 0 0 0 0 17 3 0 0 0 0 0 0 0 0 0 0 0 0 0\n",
+		pid, comm, flags)
+}
+
 type fakeAttrs map[string]string
 
 type fakeEntry struct {
@@ -0,0 +1,120 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package procs
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// kernel thread kinds
+const (
+	// KThreadPerCPU are the threads bound to a single CPU, like ksoftirqd/N or migration/N
+	KThreadPerCPU = "percpu"
+	// KThreadKworker are the unbound workqueue workers, like kworker/u8:2
+	KThreadKworker = "kworker"
+	// KThreadRCUOffload are the RCU callback offload threads, like rcuop/N
+	KThreadRCUOffload = "rcuo"
+	// KThreadIRQ are the threaded interrupt handlers, like irq/42-eth0
+	KThreadIRQ = "irq"
+	// KThreadOther are all the other kernel threads, like kswapd0 or khugepaged
+	KThreadOther = "other"
+)
+
+const (
+	// from include/linux/sched.h
+	pfKThread   = 0x00200000
+	kthreaddPID = 2
+)
+
+type KThreadInfo struct {
+	Kind string `json:"kind"`
+	// CPU is the CPU the thread is bound to (percpu) or serves (rcuo), -1 otherwise
+	CPU int `json:"cpu"`
+	// IRQ is the interrupt served by threaded handlers (irq), -1 otherwise
+	IRQ int `json:"irq"`
+}
+
+// ClassifyKThread tells the kind of a kernel thread from its name.
+func ClassifyKThread(name string) KThreadInfo {
+	info := KThreadInfo{
+		Kind: KThreadOther,
+		CPU:  -1,
+		IRQ:  -1,
+	}
+	prefix, suffix, ok := strings.Cut(name, "/")
+	if !ok {
+		return info
+	}
+	switch {
+	case prefix == "kworker":
+		// bound: kworker/3:1, kworker/3:1H, kworker/3:1-mm_percpu_wq
+		// unbound: kworker/u8:2, kworker/u8:2-events_unbound, kworker/R-rcu_gp (rescuer)
+		cpuID, _, _ := strings.Cut(suffix, ":")
+		if cpu, err := strconv.Atoi(cpuID); err == nil {
+			info.Kind = KThreadPerCPU
+			info.CPU = cpu
+			return info
+		}
+		info.Kind = KThreadKworker
+	case strings.HasPrefix(prefix, "rcuo"):
+		// rcuop/N, rcuos/N, rcuob/N and the grace period threads rcuog/N
+		info.Kind = KThreadRCUOffload
+		if cpu, err := strconv.Atoi(suffix); err == nil {
+			info.CPU = cpu
+		}
+	case prefix == "irq":
+		// irq/42-eth0-TxRx-0, irq/9-acpi
+		info.Kind = KThreadIRQ
+		irqID, _, _ := strings.Cut(suffix, "-")
+		if irq, err := strconv.Atoi(irqID); err == nil {
+			info.IRQ = irq
+		}
+	default:
+		// ksoftirqd/N, migration/N, cpuhp/N, rcuc/N, idle_inject/N...
+		if cpu, err := strconv.Atoi(suffix); err == nil {
+			info.Kind = KThreadPerCPU
+			info.CPU = cpu
+		}
+	}
+	return info
+}
+
+// fillKThreadInfo detects if the process is a kernel thread. Kernel threads have the
+// PF_KTHREAD flag set; should the flags be unreliable, we also consider the processes
+// with empty command line whose parent is kthreadd.
+func (handler *Handler) fillKThreadInfo(pidInfo *PIDInfo, cmdlineEmpty bool) {
+	st, err := handler.readStat(filepath.Join(handler.procfsRoot, procEntry(pidInfo.Pid), "stat"))
+	if err != nil {
+		// failures are not critical
+		handler.log.Printf("Error parsing stat for pid %d: %v", pidInfo.Pid, err)
+		return
+	}
+	pidInfo.PPid = st.ppid
+	isKThread := (st.flags&pfKThread) != 0 || (cmdlineEmpty && (st.ppid == kthreaddPID || pidInfo.Pid == kthreaddPID))
+	if !isKThread {
+		return
+	}
+	// kernel threads have no command line, so we need the thread name
+	name := pidInfo.Name
+	if tidInfo, ok := pidInfo.TIDs[pidInfo.Pid]; ok {
+		name = tidInfo.Name
+	}
+	kinfo := ClassifyKThread(name)
+	pidInfo.KThread = &kinfo
+}
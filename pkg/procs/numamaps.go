@@ -0,0 +1,130 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package procs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NUMAMemory is the amount of memory, in bytes, backed by pages on a NUMA node
+type NUMAMemory struct {
+	Anon uint64 `json:"anon"`
+	File uint64 `json:"file"`
+	Huge uint64 `json:"huge"`
+}
+
+func (nm NUMAMemory) Total() uint64 {
+	return nm.Anon + nm.File + nm.Huge
+}
+
+// NUMAMaps maps NUMA node ids to the memory of a process backed by pages on that node
+type NUMAMaps map[int]NUMAMemory
+
+// Nodes returns the sorted ids of the nodes backing any memory
+func (nm NUMAMaps) Nodes() []int {
+	nodes := make([]int, 0, len(nm))
+	for node, mem := range nm {
+		if mem.Total() == 0 {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Ints(nodes)
+	return nodes
+}
+
+func (handler *Handler) ReadNUMAMaps(pid int) (NUMAMaps, error) {
+	file, err := handler.fs.Open(filepath.Join(handler.procfsRoot, procEntry(pid), "numa_maps"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseNUMAMaps(file)
+}
+
+// ParseNUMAMaps parses the content of /proc/<pid>/numa_maps (see man 7 numa).
+// Each mapping is classified as a whole: hugetlbfs mappings are huge, mappings backed
+// by a file are file, everything else (heap, stack, anonymous mappings) is anon.
+// Note the private pages of a file mapping which were copied on write are thus accounted as file.
+func ParseNUMAMaps(r io.Reader) (NUMAMaps, error) {
+	res := make(NUMAMaps)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// 7f1b2c000000 default file=/dev/hugepages/rtemap_0 huge dirty=1 N0=1 kernelpagesize_kB=1048576
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		isHuge, isFile := false, false
+		pageSize := uint64(0)
+		pages := make(map[int]uint64)
+		for _, field := range fields[2:] {
+			if field == "huge" {
+				isHuge = true
+				continue
+			}
+			key, val, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			switch {
+			case key == "file":
+				isFile = true
+			case key == "kernelpagesize_kB":
+				size, err := strconv.ParseUint(val, 10, 64)
+				if err != nil {
+					return res, fmt.Errorf("error parsing page size %q: %v", field, err)
+				}
+				pageSize = size * 1024
+			case strings.HasPrefix(key, "N"):
+				node, err := strconv.Atoi(key[1:])
+				if err != nil {
+					// not a node counter
+					continue
+				}
+				count, err := strconv.ParseUint(val, 10, 64)
+				if err != nil {
+					return res, fmt.Errorf("error parsing node pages %q: %v", field, err)
+				}
+				pages[node] += count
+			}
+		}
+		if pageSize == 0 {
+			// very old kernels don't report the page size. Assume the most common one.
+			pageSize = 4096
+		}
+		for node, count := range pages {
+			mem := res[node]
+			switch {
+			case isHuge:
+				mem.Huge += count * pageSize
+			case isFile:
+				mem.File += count * pageSize
+			default:
+				mem.Anon += count * pageSize
+			}
+			res[node] = mem
+		}
+	}
+	return res, scanner.Err()
+}
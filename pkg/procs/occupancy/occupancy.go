@@ -0,0 +1,124 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package occupancy
+
+import (
+	"sort"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/counter"
+	"github.com/openshift-kni/debug-tools/pkg/procs"
+)
+
+// Usage is how much time a thread consumed on a CPU
+type Usage struct {
+	PID         int           `json:"pid"`
+	TID         int           `json:"tid"`
+	ProcessName string        `json:"process"`
+	ThreadName  string        `json:"thread"`
+	RunTime     time.Duration `json:"runtime"`
+	// UTime and STime are in clock ticks (see procs.UserHZ)
+	UTime uint64 `json:"utime"`
+	STime uint64 `json:"stime"`
+}
+
+// CPUid -> TID -> Usage
+type Stats map[int]map[int]Usage
+
+// Compute attributes the time each thread consumed between the `prev` and `last` samples
+// to the CPU it was last seen running on in `last`. This is an approximation: a thread which
+// migrated between the samples will have all its time attributed to the last CPU. The shorter
+// the sampling period, the better the approximation.
+// Only the CPUs in `cpus` are considered; threads belonging to the `ownPIDs` are skipped.
+func Compute(prev, last procs.TaskSamples, cpus cpuset.CPUSet, ownPIDs map[int]bool) Stats {
+	res := make(Stats)
+	for tid, lastSample := range last {
+		if ownPIDs[lastSample.PID] || !cpus.Contains(lastSample.Processor) {
+			continue
+		}
+		// new threads are accounted fully
+		prevSample := prev[tid]
+		if lastSample.RunTime <= prevSample.RunTime {
+			continue
+		}
+		usage := Usage{
+			PID:         lastSample.PID,
+			TID:         tid,
+			ProcessName: lastSample.ProcessName,
+			ThreadName:  lastSample.ThreadName,
+			RunTime:     time.Duration(lastSample.RunTime - prevSample.RunTime),
+			UTime:       counter.Delta(prevSample.UTime, lastSample.UTime),
+			STime:       counter.Delta(prevSample.STime, lastSample.STime),
+		}
+		cpuUsage, ok := res[lastSample.Processor]
+		if !ok {
+			cpuUsage = make(map[int]Usage)
+			res[lastSample.Processor] = cpuUsage
+		}
+		cpuUsage[tid] = usage
+	}
+	return res
+}
+
+// Add accumulates the usage in X into S
+func (S Stats) Add(X Stats) {
+	for cpuid, cpuUsage := range X {
+		acc, ok := S[cpuid]
+		if !ok {
+			acc = make(map[int]Usage)
+			S[cpuid] = acc
+		}
+		for tid, usage := range cpuUsage {
+			cur, ok := acc[tid]
+			if !ok {
+				acc[tid] = usage
+				continue
+			}
+			cur.RunTime += usage.RunTime
+			cur.UTime += usage.UTime
+			cur.STime += usage.STime
+			acc[tid] = cur
+		}
+	}
+}
+
+// Sorted returns the usages on the given CPU, sorted by decreasing runtime
+func (S Stats) Sorted(cpuid int) []Usage {
+	cpuUsage := S[cpuid]
+	res := make([]Usage, 0, len(cpuUsage))
+	for _, usage := range cpuUsage {
+		res = append(res, usage)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].RunTime == res[j].RunTime {
+			return res[i].TID < res[j].TID
+		}
+		return res[i].RunTime > res[j].RunTime
+	})
+	return res
+}
+
+// Total returns the total runtime consumed on the given CPU
+func (S Stats) Total(cpuid int) time.Duration {
+	var total time.Duration
+	for _, usage := range S[cpuid] {
+		total += usage.RunTime
+	}
+	return total
+}
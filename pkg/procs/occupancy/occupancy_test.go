@@ -0,0 +1,90 @@
+package occupancy_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/procs"
+	"github.com/openshift-kni/debug-tools/pkg/procs/occupancy"
+)
+
+var fakeSamplesInit = procs.TaskSamples{
+	10: {PID: 10, TID: 10, ProcessName: "testpmd", ThreadName: "testpmd", Processor: 2, RunTime: 1000},
+	11: {PID: 10, TID: 11, ProcessName: "testpmd", ThreadName: "lcore-worker-2", Processor: 2, RunTime: 5000},
+	20: {PID: 20, TID: 20, ProcessName: "", ThreadName: "ksoftirqd/2", Processor: 2, RunTime: 300},
+	30: {PID: 30, TID: 30, ProcessName: "chronyd", ThreadName: "chronyd", Processor: 0, RunTime: 100},
+}
+
+var fakeSamplesLast = procs.TaskSamples{
+	10: {PID: 10, TID: 10, ProcessName: "testpmd", ThreadName: "testpmd", Processor: 0, RunTime: 1500},
+	11: {PID: 10, TID: 11, ProcessName: "testpmd", ThreadName: "lcore-worker-2", Processor: 2, RunTime: 9000},
+	20: {PID: 20, TID: 20, ProcessName: "", ThreadName: "ksoftirqd/2", Processor: 2, RunTime: 700, STime: 1},
+	30: {PID: 30, TID: 30, ProcessName: "chronyd", ThreadName: "chronyd", Processor: 2, RunTime: 100},
+	// new thread, accounted fully
+	31: {PID: 30, TID: 31, ProcessName: "chronyd", ThreadName: "chronyd", Processor: 3, RunTime: 50},
+}
+
+func TestCompute(t *testing.T) {
+	cpus := cpuset.New(2, 3)
+	stats := occupancy.Compute(fakeSamplesInit, fakeSamplesLast, cpus, map[int]bool{10: true})
+
+	if len(stats) != 2 {
+		t.Fatalf("unexpected stats: %v", stats)
+	}
+	// the workload threads are skipped, the idle thread is skipped
+	if len(stats[2]) != 1 {
+		t.Fatalf("unexpected stats on cpu 2: %v", stats[2])
+	}
+	if got := stats[2][20].RunTime; got != 400 {
+		t.Errorf("unexpected runtime for tid 20: %v", got)
+	}
+	if got := stats[2][20].STime; got != 1 {
+		t.Errorf("unexpected stime for tid 20: %v", got)
+	}
+	if got := stats[3][31].RunTime; got != 50 {
+		t.Errorf("unexpected runtime for tid 31: %v", got)
+	}
+}
+
+func TestAddAndSorted(t *testing.T) {
+	total := make(occupancy.Stats)
+	total.Add(occupancy.Stats{
+		2: {20: {PID: 20, TID: 20, RunTime: 400}, 21: {PID: 21, TID: 21, RunTime: 100}},
+	})
+	total.Add(occupancy.Stats{
+		2: {21: {PID: 21, TID: 21, RunTime: 600}},
+		3: {31: {PID: 30, TID: 31, RunTime: 50}},
+	})
+
+	sorted := total.Sorted(2)
+	if len(sorted) != 2 || sorted[0].TID != 21 || sorted[0].RunTime != 700 || sorted[1].TID != 20 {
+		t.Errorf("unexpected sorted usage: %v", sorted)
+	}
+	if got := total.Total(2); got != 1100 {
+		t.Errorf("unexpected total: %v", got)
+	}
+}
+
+func TestReportingJSON(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := occupancy.NewReporter(&buf, true, 2, cpuset.New(2))
+	stats := occupancy.Stats{
+		2: {20: {PID: 20, TID: 20, ThreadName: "ksoftirqd/2", RunTime: 400}},
+		3: {31: {PID: 30, TID: 31, RunTime: 50}},
+	}
+	reporter.Summary(time.Now(), stats)
+
+	var res struct {
+		Usage occupancy.Stats `json:"usage"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &res); err != nil {
+		t.Fatalf("JSON Parser Error %v", err)
+	}
+	if len(res.Usage) != 1 || res.Usage[2][20].RunTime != 400 {
+		t.Errorf("unexpected usage: %v", res.Usage)
+	}
+}
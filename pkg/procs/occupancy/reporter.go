@@ -0,0 +1,141 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package occupancy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	cpuset "k8s.io/utils/cpuset"
+)
+
+type Reporter interface {
+	Delta(ts time.Time, delta Stats)
+	Summary(initTs time.Time, total Stats)
+}
+
+func NewReporter(sink io.Writer, jsonOutput bool, verbose int, cpus cpuset.CPUSet) Reporter {
+	if jsonOutput {
+		return &reporterJSON{
+			verbose: verbose,
+			cpus:    cpus,
+			sink:    sink,
+		}
+	}
+	return &reporterText{
+		verbose: verbose,
+		cpus:    cpus,
+		sink:    sink,
+	}
+}
+
+type reporterText struct {
+	verbose int
+	cpus    cpuset.CPUSet
+	sink    io.Writer
+}
+
+func (rt *reporterText) Delta(ts time.Time, delta Stats) {
+	if rt.verbose < 2 {
+		return
+	}
+	for _, cpuid := range rt.cpus.List() {
+		for _, usage := range delta.Sorted(cpuid) {
+			fmt.Fprintf(rt.sink, "%v CPU=%d PID=%d (%s) TID=%d (%s) +%v\n", ts, cpuid, usage.PID, usage.ProcessName, usage.TID, usage.ThreadName, usage.RunTime)
+		}
+	}
+}
+
+func (rt *reporterText) Summary(initTs time.Time, total Stats) {
+	if rt.verbose < 1 {
+		return
+	}
+	timeDelta := time.Now().Sub(initTs)
+
+	fmt.Fprintf(rt.sink, "\nCPU occupancy summary on cpus %v after %v\n", rt.cpus, timeDelta)
+	for _, cpuid := range rt.cpus.List() {
+		usages := total.Sorted(cpuid)
+		if len(usages) == 0 {
+			continue
+		}
+		fmt.Fprintf(rt.sink, "CPU=%d total=%v (%.2f%%)\n", cpuid, total.Total(cpuid), percentOf(total.Total(cpuid), timeDelta))
+		for _, usage := range usages {
+			fmt.Fprintf(rt.sink, "CPU=%d PID=%d (%s) TID=%d (%s) runtime=%v (%.2f%%) utime=%v stime=%v\n",
+				cpuid, usage.PID, usage.ProcessName, usage.TID, usage.ThreadName,
+				usage.RunTime, percentOf(usage.RunTime, timeDelta), usage.UTime, usage.STime)
+		}
+	}
+}
+
+type reporterJSON struct {
+	verbose int
+	cpus    cpuset.CPUSet
+	sink    io.Writer
+}
+
+type occupancyDelta struct {
+	Timestamp time.Time `json:"timestamp"`
+	Usage     Stats     `json:"usage"`
+}
+
+func (rj *reporterJSON) Delta(ts time.Time, delta Stats) {
+	if rj.verbose < 2 {
+		return
+	}
+	res := occupancyDelta{
+		Timestamp: ts,
+		Usage:     usageForCPUs(rj.cpus, delta),
+	}
+	json.NewEncoder(rj.sink).Encode(res)
+}
+
+type occupancySummary struct {
+	Elapsed time.Duration `json:"elapsed"`
+	Usage   Stats         `json:"usage"`
+}
+
+func (rj *reporterJSON) Summary(initTs time.Time, total Stats) {
+	if rj.verbose < 1 {
+		return
+	}
+	res := occupancySummary{
+		Elapsed: time.Now().Sub(initTs),
+		Usage:   usageForCPUs(rj.cpus, total),
+	}
+	json.NewEncoder(rj.sink).Encode(res)
+}
+
+func usageForCPUs(cpus cpuset.CPUSet, stats Stats) Stats {
+	res := make(Stats)
+	for _, cpuid := range cpus.List() {
+		cpuUsage, ok := stats[cpuid]
+		if !ok || len(cpuUsage) == 0 {
+			continue
+		}
+		res[cpuid] = cpuUsage
+	}
+	return res
+}
+
+func percentOf(val, total time.Duration) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return 100.0 * float64(val) / float64(total)
+}
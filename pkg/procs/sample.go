@@ -0,0 +1,160 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package procs
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UserHZ is the unit of the time values reported in /proc/<pid>/stat. This is fixed in the kernel ABI.
+const UserHZ = 100
+
+// TaskSample is a point-in-time snapshot of the CPU accounting of a thread
+type TaskSample struct {
+	PID         int    `json:"pid"`
+	TID         int    `json:"tid"`
+	ProcessName string `json:"process"`
+	ThreadName  string `json:"thread"`
+	// Processor is the CPU the thread last ran on
+	Processor int `json:"processor"`
+	// UTime and STime are in clock ticks (see UserHZ)
+	UTime uint64 `json:"utime"`
+	STime uint64 `json:"stime"`
+	// RunTime is the time spent on the CPU, in nanoseconds, from /proc/<pid>/task/<tid>/schedstat.
+	// If schedstat is not available, this is computed from UTime and STime, thus with far less precision.
+	RunTime uint64 `json:"runtime"`
+}
+
+// TaskSamples maps TIDs to the samples of their threads
+type TaskSamples map[int]TaskSample
+
+// TicksToDuration converts clock ticks, like the ones reported in /proc/<pid>/stat, to a Duration.
+func TicksToDuration(ticks uint64) time.Duration {
+	return time.Duration(ticks) * (time.Second / UserHZ)
+}
+
+// SampleAll samples all the threads of all the processes.
+// Processes or threads which disappear while sampling are silently skipped.
+func (handler *Handler) SampleAll() (TaskSamples, error) {
+	samples := make(TaskSamples)
+	pidEntries, err := handler.fs.ReadDir(handler.procfsRoot)
+	if err != nil {
+		return samples, err
+	}
+
+	for _, pidEntry := range pidEntries {
+		if !pidEntry.IsDir() {
+			continue
+		}
+		pid, err := strconv.Atoi(pidEntry.Name())
+		if err != nil {
+			// doesn't look like a pid
+			continue
+		}
+
+		if err := handler.samplePID(pid, samples); err != nil {
+			handler.log.Printf("Error sampling PID %d: %v", pid, err)
+			continue
+		}
+	}
+	return samples, nil
+}
+
+// SamplePID samples all the threads of the given process.
+func (handler *Handler) SamplePID(pid int) (TaskSamples, error) {
+	samples := make(TaskSamples)
+	return samples, handler.samplePID(pid, samples)
+}
+
+func (handler *Handler) samplePID(pid int, samples TaskSamples) error {
+	procName, err := handler.readProcessName(pid)
+	if err != nil {
+		// failures are not critical
+		handler.log.Printf("Error reading process name for pid %d: %v", pid, err)
+	}
+	procName = fixFilename(procName)
+
+	tasksDir := filepath.Join(handler.procfsRoot, procEntry(pid), "task")
+	tidEntries, err := handler.fs.ReadDir(tasksDir)
+	if err != nil {
+		return err
+	}
+
+	for _, tidEntry := range tidEntries {
+		tid, err := strconv.Atoi(tidEntry.Name())
+		if err != nil {
+			continue
+		}
+		taskDir := filepath.Join(tasksDir, tidEntry.Name())
+		data, err := handler.fs.ReadFile(filepath.Join(taskDir, "stat"))
+		if err != nil {
+			// the thread may be gone meantime
+			handler.log.Printf("Error reading stat for pid %d tid %d: %v", pid, tid, err)
+			continue
+		}
+		st, err := parseStat(string(data))
+		if err != nil {
+			handler.log.Printf("Error parsing stat for pid %d tid %d: %v", pid, tid, err)
+			continue
+		}
+
+		sample := TaskSample{
+			PID:         pid,
+			TID:         tid,
+			ProcessName: procName,
+			ThreadName:  parseStatComm(string(data)),
+			Processor:   st.processor,
+			UTime:       st.utime,
+			STime:       st.stime,
+		}
+		runTime, err := handler.readSchedStat(filepath.Join(taskDir, "schedstat"))
+		if err != nil {
+			handler.log.Printf("Error reading schedstat for pid %d tid %d: %v", pid, tid, err)
+			runTime = uint64(TicksToDuration(st.utime + st.stime).Nanoseconds())
+		}
+		sample.RunTime = runTime
+		samples[tid] = sample
+	}
+	return nil
+}
+
+// readSchedStat returns the time spent on the cpu, in nanoseconds.
+// The file format is "<time on cpu> <time waiting on a runqueue> <# of timeslices>"
+func (handler *Handler) readSchedStat(path string) (uint64, error) {
+	data, err := handler.fs.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	items := strings.Fields(string(data))
+	if len(items) < 1 {
+		return 0, fmt.Errorf("malformed schedstat content: %q", string(data))
+	}
+	return strconv.ParseUint(items[0], 10, 64)
+}
+
+func parseStatComm(data string) string {
+	start := strings.Index(data, "(")
+	end := strings.LastIndex(data, ")")
+	if start < 0 || end < start {
+		return ""
+	}
+	return data[start+1 : end]
+}
@@ -0,0 +1,217 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package procs
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// scheduling policies, see man 7 sched
+const (
+	PolicyOther    = "OTHER"
+	PolicyFIFO     = "FIFO"
+	PolicyRR       = "RR"
+	PolicyBatch    = "BATCH"
+	PolicyIdle     = "IDLE"
+	PolicyDeadline = "DEADLINE"
+)
+
+// the values are from include/uapi/linux/sched.h
+var policyNames = map[int]string{
+	0: PolicyOther,
+	1: PolicyFIFO,
+	2: PolicyRR,
+	3: PolicyBatch,
+	5: PolicyIdle,
+	6: PolicyDeadline,
+}
+
+// PolicyName returns the name of the scheduling policy from its numeric kernel value
+func PolicyName(policy int) string {
+	if name, ok := policyNames[policy]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", policy)
+}
+
+// ParsePolicy returns the canonical name of a scheduling policy. Parsing is case-insensitive
+// and accepts the "SCHED_" prefix, so "fifo", "FIFO" and "SCHED_FIFO" are all the same.
+func ParsePolicy(name string) (string, error) {
+	policy := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(name)), "SCHED_")
+	for _, known := range policyNames {
+		if policy == known {
+			return known, nil
+		}
+	}
+	return "", fmt.Errorf("unknown scheduling policy %q", name)
+}
+
+// IsRealTime returns true if the policy is one of the realtime ones
+func IsRealTime(policy string) bool {
+	return policy == PolicyFIFO || policy == PolicyRR || policy == PolicyDeadline
+}
+
+// procStat holds the fields we care about from /proc/<pid>/task/<tid>/stat.
+// See man 5 proc for the full description.
+type procStat struct {
+	state      string
+	ppid       int
+	flags      uint64
+	utime      uint64
+	stime      uint64
+	nice       int
+	processor  int
+	rtPriority int
+	policy     int
+}
+
+// stat fields, 1-based as in man 5 proc
+const (
+	statFieldState      = 3
+	statFieldPPid       = 4
+	statFieldFlags      = 9
+	statFieldUTime      = 14
+	statFieldSTime      = 15
+	statFieldNice       = 19
+	statFieldProcessor  = 39
+	statFieldRTPriority = 40
+	statFieldPolicy     = 41
+)
+
+func parseStat(data string) (procStat, error) {
+	st := procStat{}
+	// the comm field can contain spaces and parens, so we need to look for the last closing paren.
+	off := strings.LastIndex(data, ")")
+	if off < 0 {
+		return st, fmt.Errorf("malformed stat content: missing comm")
+	}
+	items := strings.Fields(data[off+1:])
+	// items[0] is field #3 (state)
+	field := func(num int) (string, error) {
+		idx := num - statFieldState
+		if idx >= len(items) {
+			return "", fmt.Errorf("malformed stat content: missing field %d", num)
+		}
+		return items[idx], nil
+	}
+	parseInt := func(num int) (int, error) {
+		item, err := field(num)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.Atoi(item)
+	}
+	parseUint := func(num int) (uint64, error) {
+		item, err := field(num)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseUint(item, 10, 64)
+	}
+
+	var err error
+	if st.state, err = field(statFieldState); err != nil {
+		return st, err
+	}
+	if st.ppid, err = parseInt(statFieldPPid); err != nil {
+		return st, err
+	}
+	if st.flags, err = parseUint(statFieldFlags); err != nil {
+		return st, err
+	}
+	if st.utime, err = parseUint(statFieldUTime); err != nil {
+		return st, err
+	}
+	if st.stime, err = parseUint(statFieldSTime); err != nil {
+		return st, err
+	}
+	if st.nice, err = parseInt(statFieldNice); err != nil {
+		return st, err
+	}
+	if st.processor, err = parseInt(statFieldProcessor); err != nil {
+		return st, err
+	}
+	if st.rtPriority, err = parseInt(statFieldRTPriority); err != nil {
+		return st, err
+	}
+	if st.policy, err = parseInt(statFieldPolicy); err != nil {
+		return st, err
+	}
+	return st, nil
+}
+
+func (handler *Handler) readStat(path string) (procStat, error) {
+	data, err := handler.fs.ReadFile(path)
+	if err != nil {
+		return procStat{}, err
+	}
+	return parseStat(string(data))
+}
+
+// readSched extracts the scheduling policy, the realtime priority and the nice value from the
+// /proc/<pid>/task/<tid>/sched debug file. We use this as fallback if the stat file is
+// unavailable or truncated. Only the scheduling fields of the returned procStat are set.
+func (handler *Handler) readSched(path string) (procStat, error) {
+	st := procStat{}
+	file, err := handler.fs.Open(path)
+	if err != nil {
+		return st, err
+	}
+	defer file.Close()
+
+	prio := 0
+	foundPolicy, foundPrio := false, false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// format is like "policy                                       :                    1"
+		items := strings.SplitN(scanner.Text(), ":", 2)
+		if len(items) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(items[0])
+		if key != "policy" && key != "prio" {
+			continue
+		}
+		val, err := strconv.Atoi(strings.TrimSpace(items[1]))
+		if err != nil {
+			return st, err
+		}
+		if key == "policy" {
+			st.policy, foundPolicy = val, true
+		} else {
+			prio, foundPrio = val, true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return st, err
+	}
+	if !foundPolicy || !foundPrio {
+		return st, fmt.Errorf("missing policy or prio in %q", path)
+	}
+	// the kernel reports the internal priority, see include/linux/sched/prio.h:
+	// prio = MAX_RT_PRIO - 1 - rt_priority for realtime tasks, prio = DEFAULT_PRIO + nice otherwise.
+	switch PolicyName(st.policy) {
+	case PolicyFIFO, PolicyRR:
+		st.rtPriority = 99 - prio
+	case PolicyOther, PolicyBatch, PolicyIdle:
+		st.nice = prio - 120
+	}
+	return st, nil
+}
@@ -0,0 +1,211 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package sriov
+
+import (
+	"bufio"
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-kni/debug-tools/pkg/fswrap"
+	"github.com/openshift-kni/debug-tools/pkg/pcidev"
+)
+
+// VF is a virtual function. Iface, MAC and VLAN are empty if not readable: Iface and MAC require
+// the VF to be bound to a network driver (e.g. not vfio-pci), VLAN requires the PF driver to expose it.
+type VF struct {
+	Index    int    `json:"index"`
+	Address  string `json:"address"`
+	Driver   string `json:"driver,omitempty"`
+	NUMANode int    `json:"numaNode"`
+	Iface    string `json:"iface,omitempty"`
+	MAC      string `json:"mac,omitempty"`
+	VLAN     string `json:"vlan,omitempty"`
+}
+
+// PF is a physical function with SR-IOV capabilities
+type PF struct {
+	Address  string   `json:"address"`
+	Driver   string   `json:"driver,omitempty"`
+	NUMANode int      `json:"numaNode"`
+	Ifaces   []string `json:"ifaces"`
+	NumVFs   int      `json:"numVFs"`
+	TotalVFs int      `json:"totalVFs"`
+	VFs      []VF     `json:"vfs"`
+}
+
+// Matches tells if `name` is the PCI address of the PF, with or without the domain, or one of its interfaces.
+func (pf PF) Matches(name string) bool {
+	if name == pf.Address || name == strings.TrimPrefix(pf.Address, "0000:") {
+		return true
+	}
+	for _, iface := range pf.Ifaces {
+		if iface == name {
+			return true
+		}
+	}
+	return false
+}
+
+type Handler struct {
+	log       *log.Logger
+	sysfsRoot string
+	fs        fswrap.FSWrapper
+}
+
+func New(logger *log.Logger, sysfsRoot string) *Handler {
+	return &Handler{
+		log:       logger,
+		sysfsRoot: sysfsRoot,
+		fs:        fswrap.FSWrapper{Log: logger},
+	}
+}
+
+// ReadPFs finds the PFs through their network interfaces, and reads their VFs. PFs are sorted
+// by address, VFs by index.
+func (handler *Handler) ReadPFs() ([]PF, error) {
+	devs, err := pcidev.New(handler.log, handler.sysfsRoot).ReadDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	netRoot := filepath.Join(handler.sysfsRoot, "class", "net")
+	entries, err := handler.fs.ReadDir(netRoot)
+	if err != nil {
+		return nil, err
+	}
+	pfs := make(map[string]PF)
+	for _, entry := range entries {
+		devDir := filepath.Join(netRoot, entry.Name(), "device")
+		totalVFs, err := handler.readInt(filepath.Join(devDir, "sriov_totalvfs"))
+		if err != nil {
+			continue // not a PF
+		}
+		devPath, err := handler.fs.EvalSymlinks(devDir)
+		if err != nil {
+			handler.log.Printf("Error resolving the device of %q: %v", entry.Name(), err)
+			continue
+		}
+		addr := filepath.Base(devPath)
+		if _, ok := pfs[addr]; ok {
+			continue // another interface of the same PF, like a representor
+		}
+		dev, ok := devs[addr]
+		if !ok {
+			// should not happen, but we can still report what the interface tells us
+			dev = pcidev.Device{NUMANode: -1, Ifaces: []string{entry.Name()}}
+		}
+		pf := PF{
+			Address:  addr,
+			Driver:   dev.Driver,
+			NUMANode: dev.NUMANode,
+			Ifaces:   dev.Ifaces,
+			TotalVFs: totalVFs,
+		}
+		pf.NumVFs, err = handler.readInt(filepath.Join(devDir, "sriov_numvfs"))
+		if err != nil {
+			handler.log.Printf("Error reading the number of VFs of %q: %v", entry.Name(), err)
+		}
+		pf.VFs = handler.readVFs(devDir, devs)
+		pfs[addr] = pf
+	}
+
+	res := make([]PF, 0, len(pfs))
+	for _, pf := range pfs {
+		res = append(res, pf)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Address < res[j].Address })
+	return res, nil
+}
+
+func (handler *Handler) readVFs(devDir string, devs pcidev.Devices) []VF {
+	entries, err := handler.fs.ReadDir(devDir)
+	if err != nil {
+		handler.log.Printf("Error reading %q: %v", devDir, err)
+		return nil
+	}
+	var vfs []VF
+	for _, entry := range entries {
+		idx, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), "virtfn"))
+		if err != nil || !strings.HasPrefix(entry.Name(), "virtfn") {
+			continue
+		}
+		vfPath, err := handler.fs.EvalSymlinks(filepath.Join(devDir, entry.Name()))
+		if err != nil {
+			handler.log.Printf("Error resolving VF %d: %v", idx, err)
+			continue
+		}
+		addr := filepath.Base(vfPath)
+		vf := VF{
+			Index:    idx,
+			Address:  addr,
+			NUMANode: -1,
+		}
+		if dev, ok := devs[addr]; ok {
+			vf.Driver = dev.Driver
+			vf.NUMANode = dev.NUMANode
+			if len(dev.Ifaces) > 0 {
+				vf.Iface = dev.Ifaces[0]
+			}
+		}
+		if vf.Iface != "" {
+			if data, err := handler.fs.ReadFile(filepath.Join(handler.sysfsRoot, "class", "net", vf.Iface, "address")); err == nil {
+				vf.MAC = strings.TrimSpace(string(data))
+			}
+		}
+		// mlx5 in legacy mode exposes the administrative settings of the VFs
+		if config, err := handler.readVFConfig(filepath.Join(devDir, "sriov", strconv.Itoa(idx), "config")); err == nil {
+			if vf.MAC == "" {
+				vf.MAC = config["MAC"]
+			}
+			vf.VLAN = config["VLAN"]
+		}
+		vfs = append(vfs, vf)
+	}
+	sort.Slice(vfs, func(i, j int) bool { return vfs[i].Index < vfs[j].Index })
+	return vfs
+}
+
+// readVFConfig parses the "Key : Value" lines of the mlx5 VF configuration. Keys are upper case.
+func (handler *Handler) readVFConfig(path string) (map[string]string, error) {
+	src, err := handler.fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+	res := make(map[string]string)
+	scanner := bufio.NewScanner(src)
+	for scanner.Scan() {
+		key, val, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		res[strings.ToUpper(strings.TrimSpace(key))] = strings.TrimSpace(val)
+	}
+	return res, scanner.Err()
+}
+
+func (handler *Handler) readInt(path string) (int, error) {
+	data, err := handler.fs.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
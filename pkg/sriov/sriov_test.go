@@ -0,0 +1,148 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package sriov_test
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openshift-kni/debug-tools/internal/fakefs"
+	"github.com/openshift-kni/debug-tools/pkg/sriov"
+)
+
+var nullLog = log.New(ioutil.Discard, "", 0)
+
+func TestReadPFs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	defer os.RemoveAll(dir) // clean up
+
+	devicesDir := filepath.Join(dir, "devices", "pci0000:3a")
+	fakefs.Mkdir(t, filepath.Join(dir, "bus", "pci", "devices"))
+	fakefs.Mkdir(t, filepath.Join(dir, "class", "net", "lo"))
+	addDevice := func(addr, driver, node string, ifaces ...string) string {
+		devDir := filepath.Join(devicesDir, addr)
+		fakefs.Mkdir(t, devDir)
+		fakefs.WriteFile(t, filepath.Join(devDir, "numa_node"), node+"\n")
+		fakefs.Symlink(t, "../../../devices/pci0000:3a/"+addr, filepath.Join(dir, "bus", "pci", "devices", addr))
+		if driver != "" {
+			fakefs.Mkdir(t, filepath.Join(dir, "bus", "pci", "drivers", driver))
+			fakefs.Symlink(t, "../../../bus/pci/drivers/"+driver, filepath.Join(devDir, "driver"))
+		}
+		for _, iface := range ifaces {
+			fakefs.Mkdir(t, filepath.Join(devDir, "net", iface))
+			fakefs.Symlink(t, "../../../"+addr, filepath.Join(devDir, "net", iface, "device"))
+			fakefs.Symlink(t, "../../devices/pci0000:3a/"+addr+"/net/"+iface, filepath.Join(dir, "class", "net", iface))
+		}
+		return devDir
+	}
+
+	// i40e PF with a VF bound to iavf and another to vfio-pci
+	pfDir := addDevice("0000:3b:00.0", "i40e", "0", "ens1f0")
+	fakefs.WriteFile(t, filepath.Join(pfDir, "sriov_totalvfs"), "64\n")
+	fakefs.WriteFile(t, filepath.Join(pfDir, "sriov_numvfs"), "2\n")
+	fakefs.Symlink(t, "../0000:3b:02.0", filepath.Join(pfDir, "virtfn0"))
+	fakefs.Symlink(t, "../0000:3b:02.1", filepath.Join(pfDir, "virtfn1"))
+	vfDir := addDevice("0000:3b:02.0", "iavf", "0", "ens1f0v0")
+	fakefs.WriteFile(t, filepath.Join(vfDir, "net", "ens1f0v0", "address"), "aa:bb:cc:00:00:01\n")
+	addDevice("0000:3b:02.1", "vfio-pci", "0")
+
+	// mlx5 PF with a representor and the VF settings exposed by the driver
+	pfDir = addDevice("0000:af:00.0", "mlx5_core", "1", "ens2f0", "ens2f0_0")
+	fakefs.WriteFile(t, filepath.Join(pfDir, "sriov_totalvfs"), "8\n")
+	fakefs.WriteFile(t, filepath.Join(pfDir, "sriov_numvfs"), "1\n")
+	fakefs.Symlink(t, "../0000:af:00.2", filepath.Join(pfDir, "virtfn0"))
+	fakefs.Mkdir(t, filepath.Join(pfDir, "sriov", "0"))
+	fakefs.WriteFile(t, filepath.Join(pfDir, "sriov", "0", "config"), "VF         : 0\nMAC        : 02:00:00:00:00:02\nVLAN       : 100\nQoS        : 0\n")
+	addDevice("0000:af:00.2", "vfio-pci", "1")
+
+	// PF with SR-IOV disabled
+	pfDir = addDevice("0000:d8:00.0", "ice", "1", "ens3f0")
+	fakefs.WriteFile(t, filepath.Join(pfDir, "sriov_totalvfs"), "128\n")
+	fakefs.WriteFile(t, filepath.Join(pfDir, "sriov_numvfs"), "0\n")
+
+	pfs, err := sriov.New(nullLog, dir).ReadPFs()
+	if err != nil {
+		t.Fatalf("ReadPFs failed: %v", err)
+	}
+
+	expected := []sriov.PF{
+		{
+			Address:  "0000:3b:00.0",
+			Driver:   "i40e",
+			NUMANode: 0,
+			Ifaces:   []string{"ens1f0"},
+			NumVFs:   2,
+			TotalVFs: 64,
+			VFs: []sriov.VF{
+				{Index: 0, Address: "0000:3b:02.0", Driver: "iavf", NUMANode: 0, Iface: "ens1f0v0", MAC: "aa:bb:cc:00:00:01"},
+				{Index: 1, Address: "0000:3b:02.1", Driver: "vfio-pci", NUMANode: 0},
+			},
+		},
+		{
+			Address:  "0000:af:00.0",
+			Driver:   "mlx5_core",
+			NUMANode: 1,
+			Ifaces:   []string{"ens2f0", "ens2f0_0"},
+			NumVFs:   1,
+			TotalVFs: 8,
+			VFs: []sriov.VF{
+				{Index: 0, Address: "0000:af:00.2", Driver: "vfio-pci", NUMANode: 1, MAC: "02:00:00:00:00:02", VLAN: "100"},
+			},
+		},
+		{
+			Address:  "0000:d8:00.0",
+			Driver:   "ice",
+			NUMANode: 1,
+			Ifaces:   []string{"ens3f0"},
+			TotalVFs: 128,
+		},
+	}
+	if !reflect.DeepEqual(pfs, expected) {
+		t.Errorf("unexpected PFs:\ngot=%+v\nexpected=%+v", pfs, expected)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	pf := sriov.PF{
+		Address: "0000:3b:00.0",
+		Ifaces:  []string{"ens1f0", "ens1f0_0"},
+	}
+	testCases := []struct {
+		name     string
+		expected bool
+	}{
+		{"0000:3b:00.0", true},
+		{"3b:00.0", true},
+		{"ens1f0_0", true},
+		{"ens1f1", false},
+		{"3b:00.1", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := pf.Matches(tc.name); got != tc.expected {
+				t.Errorf("got=%v expected=%v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package workqueue
+
+import (
+	"fmt"
+	"path/filepath"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/cpumask"
+)
+
+// Write is a write of a cpumask attribute. The cpus are in the cpulist format, Mask is what to write.
+type Write struct {
+	Path string `json:"path"`
+	// Workqueue is empty for the global cpumask
+	Workqueue string `json:"workqueue,omitempty"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Mask      string `json:"mask"`
+}
+
+// String returns the write as a shell command
+func (wr Write) String() string {
+	return fmt.Sprintf("echo %s > %s # %s -> %s", wr.Mask, wr.Path, wr.From, wr.To)
+}
+
+// Plan computes the writes needed to confine the unbound workqueues to the `housekeeping` cpus,
+// starting from the global cpumask. Like for the IRQs, we keep the housekeeping cpus of the current
+// cpumask if any, otherwise we use all the housekeeping cpus. The per-cpu workqueues cannot be moved.
+func (handler *Handler) Plan(info Info, housekeeping cpuset.CPUSet) []Write {
+	root := handler.Root()
+	var writes []Write
+	if wr, ok := planWrite(filepath.Join(root, "cpumask"), info.UnboundCPUs, housekeeping); ok {
+		writes = append(writes, wr)
+	}
+	for _, wq := range info.Workqueues {
+		if wq.CPUs == nil {
+			continue
+		}
+		if wr, ok := planWrite(filepath.Join(root, wq.Name, "cpumask"), *wq.CPUs, housekeeping); ok {
+			wr.Workqueue = wq.Name
+			writes = append(writes, wr)
+		}
+	}
+	return writes
+}
+
+func planWrite(path string, cpus, housekeeping cpuset.CPUSet) (Write, bool) {
+	if cpus.IsSubsetOf(housekeeping) {
+		return Write{}, false
+	}
+	target := cpus.Intersection(housekeeping)
+	if target.Size() == 0 {
+		target = housekeeping
+	}
+	return Write{
+		Path: path,
+		From: cpus.String(),
+		To:   target.String(),
+		Mask: cpumask.Format(target),
+	}, true
+}
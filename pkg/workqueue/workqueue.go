@@ -0,0 +1,148 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package workqueue
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/pkg/cpumask"
+	"github.com/openshift-kni/debug-tools/pkg/fswrap"
+)
+
+// Workqueue is a workqueue the kernel exposes in sysfs (WQ_SYSFS).
+type Workqueue struct {
+	Name   string `json:"name"`
+	PerCPU bool   `json:"perCPU"`
+	Nice   int    `json:"nice"`
+	// CPUs is the cpumask of the unbound workqueue, nil for the per-cpu workqueues
+	CPUs *cpuset.CPUSet `json:"-"`
+}
+
+type Info struct {
+	// UnboundCPUs is the global cpumask of the unbound workqueues
+	UnboundCPUs cpuset.CPUSet
+	Workqueues  []Workqueue
+}
+
+// EffectiveCPUs returns the cpus the unbound workqueue `wq` can run on: the kernel restricts the
+// workqueue cpumask to the global one, falling back to the global one if they don't intersect.
+func (info Info) EffectiveCPUs(wq Workqueue) cpuset.CPUSet {
+	if wq.CPUs == nil {
+		return cpuset.New()
+	}
+	cpus := wq.CPUs.Intersection(info.UnboundCPUs)
+	if cpus.Size() == 0 {
+		return info.UnboundCPUs
+	}
+	return cpus
+}
+
+// Overlapping returns the unbound workqueues which can run on any of `cpus`.
+func (info Info) Overlapping(cpus cpuset.CPUSet) []Workqueue {
+	var res []Workqueue
+	for _, wq := range info.Workqueues {
+		if wq.PerCPU {
+			continue
+		}
+		if info.EffectiveCPUs(wq).Intersection(cpus).Size() > 0 {
+			res = append(res, wq)
+		}
+	}
+	return res
+}
+
+type Handler struct {
+	log       *log.Logger
+	sysfsRoot string
+	fs        fswrap.FSWrapper
+}
+
+func New(logger *log.Logger, sysfsRoot string) *Handler {
+	return &Handler{
+		log:       logger,
+		sysfsRoot: sysfsRoot,
+		fs:        fswrap.FSWrapper{Log: logger},
+	}
+}
+
+func (handler *Handler) Root() string {
+	return filepath.Join(handler.sysfsRoot, "devices", "virtual", "workqueue")
+}
+
+// ReadInfo reads the global cpumask and the attributes of the workqueues, sorted by name.
+func (handler *Handler) ReadInfo() (Info, error) {
+	info := Info{}
+	root := handler.Root()
+	cpus, err := handler.readMask(filepath.Join(root, "cpumask"))
+	if err != nil {
+		return info, err
+	}
+	info.UnboundCPUs = cpus
+
+	entries, err := handler.fs.ReadDir(root)
+	if err != nil {
+		return info, err
+	}
+	for _, entry := range entries {
+		wqDir := filepath.Join(root, entry.Name())
+		perCPU, err := handler.fs.ReadFile(filepath.Join(wqDir, "per_cpu"))
+		if err != nil {
+			continue // not a workqueue, e.g. "power" or "uevent"
+		}
+		wq := Workqueue{
+			Name:   entry.Name(),
+			PerCPU: strings.TrimSpace(string(perCPU)) == "1",
+		}
+		if nice, err := handler.fs.ReadFile(filepath.Join(wqDir, "nice")); err == nil {
+			wq.Nice, err = strconv.Atoi(strings.TrimSpace(string(nice)))
+			if err != nil {
+				handler.log.Printf("Error parsing the nice value of workqueue %q: %v", wq.Name, err)
+			}
+		}
+		if !wq.PerCPU {
+			// the unbound workqueues only have the cpumask attribute
+			cpus, err := handler.readMask(filepath.Join(wqDir, "cpumask"))
+			if err != nil {
+				handler.log.Printf("Error reading the cpumask of workqueue %q: %v", wq.Name, err)
+			} else {
+				wq.CPUs = &cpus
+			}
+		}
+		info.Workqueues = append(info.Workqueues, wq)
+	}
+	sort.Slice(info.Workqueues, func(i, j int) bool { return info.Workqueues[i].Name < info.Workqueues[j].Name })
+	return info, nil
+}
+
+func (handler *Handler) readMask(path string) (cpuset.CPUSet, error) {
+	data, err := handler.fs.ReadFile(path)
+	if err != nil {
+		return cpuset.New(), err
+	}
+	cpus, err := cpumask.Parse(string(data))
+	if err != nil {
+		return cpuset.New(), fmt.Errorf("error parsing %q: %v", path, err)
+	}
+	return cpus, nil
+}
@@ -0,0 +1,128 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package workqueue_test
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	cpuset "k8s.io/utils/cpuset"
+
+	"github.com/openshift-kni/debug-tools/internal/fakefs"
+	"github.com/openshift-kni/debug-tools/pkg/workqueue"
+)
+
+var nullLog = log.New(ioutil.Discard, "", 0)
+
+func TestReadInfo(t *testing.T) {
+	sysDir := makeFakeSysFS(t)
+	defer os.RemoveAll(sysDir) // clean up
+
+	info, err := workqueue.New(nullLog, sysDir).ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo failed: %v", err)
+	}
+	if !info.UnboundCPUs.Equals(cpuset.New(0, 1, 2, 3, 4, 5, 6, 7)) {
+		t.Errorf("unexpected unbound cpumask: %v", info.UnboundCPUs)
+	}
+
+	var names []string
+	for _, wq := range info.Workqueues {
+		names = append(names, wq.Name)
+	}
+	expectedNames := []string{"blkcg_punt_bio", "nvme-wq", "scsi_tmf_0", "writeback"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Fatalf("unexpected workqueues: got %v expected %v", names, expectedNames)
+	}
+	if wq := info.Workqueues[1]; !wq.PerCPU || wq.CPUs != nil {
+		t.Errorf("nvme-wq should be per-cpu: %+v", wq)
+	}
+	if wq := info.Workqueues[3]; wq.PerCPU || wq.CPUs == nil || !wq.CPUs.Equals(cpuset.New(0, 1, 2, 3, 4, 5, 6, 7)) || wq.Nice != 0 {
+		t.Errorf("unexpected writeback: %+v", wq)
+	}
+	if wq := info.Workqueues[2]; wq.Nice != -20 {
+		t.Errorf("unexpected scsi_tmf_0 nice: %d", wq.Nice)
+	}
+
+	var overlapping []string
+	for _, wq := range info.Overlapping(cpuset.New(4, 5, 6, 7)) {
+		overlapping = append(overlapping, wq.Name)
+	}
+	// scsi_tmf_0 is confined to 0-1, blkcg_punt_bio to 8-9, which falls back to the global cpumask
+	expectedOverlapping := []string{"blkcg_punt_bio", "writeback"}
+	if !reflect.DeepEqual(overlapping, expectedOverlapping) {
+		t.Errorf("unexpected overlapping workqueues: got %v expected %v", overlapping, expectedOverlapping)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	sysDir := makeFakeSysFS(t)
+	defer os.RemoveAll(sysDir) // clean up
+
+	wh := workqueue.New(nullLog, sysDir)
+	info, err := wh.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo failed: %v", err)
+	}
+
+	root := filepath.Join(sysDir, "devices", "virtual", "workqueue")
+	got := wh.Plan(info, cpuset.New(0, 1, 2, 3))
+	expected := []workqueue.Write{
+		{Path: filepath.Join(root, "cpumask"), From: "0-7", To: "0-3", Mask: "0000000f"},
+		{Path: filepath.Join(root, "blkcg_punt_bio", "cpumask"), Workqueue: "blkcg_punt_bio", From: "8-9", To: "0-3", Mask: "0000000f"},
+		{Path: filepath.Join(root, "writeback", "cpumask"), Workqueue: "writeback", From: "0-7", To: "0-3", Mask: "0000000f"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected plan\ngot=%+v\nexpected=%+v", got, expected)
+	}
+
+	if got := wh.Plan(info, cpuset.New(0, 1, 2, 3, 4, 5, 6, 7, 8, 9)); len(got) != 0 {
+		t.Errorf("unexpected plan for all the cpus: %+v", got)
+	}
+}
+
+func makeFakeSysFS(t *testing.T) string {
+	t.Helper()
+	rootDir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("creating temp dir %v", err)
+	}
+	root := filepath.Join(rootDir, "devices", "virtual", "workqueue")
+	fakefs.WriteFile(t, filepath.Join(root, "cpumask"), "ff\n")
+	fakefs.WriteFile(t, filepath.Join(root, "uevent"), "")
+	fakefs.WriteFile(t, filepath.Join(root, "power", "control"), "auto\n")
+
+	fakefs.WriteFile(t, filepath.Join(root, "writeback", "per_cpu"), "0\n")
+	fakefs.WriteFile(t, filepath.Join(root, "writeback", "nice"), "0\n")
+	fakefs.WriteFile(t, filepath.Join(root, "writeback", "cpumask"), "000000ff\n")
+
+	fakefs.WriteFile(t, filepath.Join(root, "scsi_tmf_0", "per_cpu"), "0\n")
+	fakefs.WriteFile(t, filepath.Join(root, "scsi_tmf_0", "nice"), "-20\n")
+	fakefs.WriteFile(t, filepath.Join(root, "scsi_tmf_0", "cpumask"), "00000003\n")
+
+	fakefs.WriteFile(t, filepath.Join(root, "blkcg_punt_bio", "per_cpu"), "0\n")
+	fakefs.WriteFile(t, filepath.Join(root, "blkcg_punt_bio", "nice"), "0\n")
+	fakefs.WriteFile(t, filepath.Join(root, "blkcg_punt_bio", "cpumask"), "00000300\n")
+
+	fakefs.WriteFile(t, filepath.Join(root, "nvme-wq", "per_cpu"), "1\n")
+	fakefs.WriteFile(t, filepath.Join(root, "nvme-wq", "nice"), "0\n")
+	return rootDir
+}